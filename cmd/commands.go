@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/a-h/linear"
+)
+
+const systemArguments = "[file]"
+
+// systemCommand parses the flags of a command which reads a system of equations, and reads the system.
+func systemCommand(env environment, name string, args []string, outputs ...string) (*systemFlags, linear.NamedSystem, error) {
+	fs := newFlagSet(name, systemArguments, env)
+	f := newSystemFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, linear.NamedSystem{}, err
+	}
+	if err := checkFormat("out", f.out, outputs...); err != nil {
+		return nil, linear.NamedSystem{}, err
+	}
+	ns, err := readSystem(env, fs.Args(), f)
+	return f, ns, err
+}
+
+var (
+	errNoSolution        = exitCodeError{code: exitNoSolution, err: errors.New("the system has no solution")}
+	errInfiniteSolutions = exitCodeError{code: exitInfiniteSolutions, err: errors.New("the system has infinitely many solutions, use parameterize to describe them")}
+)
+
+func solve(env environment, args []string) error {
+	f, ns, err := systemCommand(env, "solve", args, textFormat, csvFormat, jsonFormat, latexFormat)
+	if err != nil {
+		return err
+	}
+	solution, noSolution, infiniteSolutions, err := ns.System.Solve()
+	if err != nil {
+		return err
+	}
+	if noSolution {
+		return errNoSolution
+	}
+	if infiniteSolutions {
+		return errInfiniteSolutions
+	}
+	switch f.out {
+	case csvFormat:
+		// Write the solution as a row, with the same columns as the coefficients of the input.
+		w := csv.NewWriter(env.stdout)
+		if f.header {
+			w.Write(ns.Variables)
+		}
+		record := make([]string, len(solution))
+		for i, value := range solution {
+			record[i] = linear.Formatter{Verb: 'g', Precision: -1}.Number(value)
+		}
+		w.Write(record)
+		w.Flush()
+		return w.Error()
+	case textFormat:
+		for i, value := range solution {
+			if _, err := fmt.Fprintf(env.stdout, "%s = %s\n", ns.Variables[i], linear.Formatter{}.Number(value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return writeVector(env.stdout, f.out, solution)
+}
+
+func rref(env environment, args []string) error {
+	f, ns, err := systemCommand(env, "rref", args, textFormat, csvFormat, jsonFormat, latexFormat)
+	if err != nil {
+		return err
+	}
+	s, _, err := ns.System.ComputeRREF()
+	if err != nil {
+		return err
+	}
+	return writeSystem(env.stdout, f, ns.Variables, s)
+}
+
+func triangular(env environment, args []string) error {
+	f, ns, err := systemCommand(env, "triangular", args, textFormat, csvFormat, jsonFormat, latexFormat)
+	if err != nil {
+		return err
+	}
+	s, err := ns.System.TriangularForm()
+	if err != nil {
+		return err
+	}
+	return writeSystem(env.stdout, f, ns.Variables, s)
+}
+
+func parameterize(env environment, args []string) error {
+	f, ns, err := systemCommand(env, "parameterize", args, textFormat, jsonFormat, latexFormat)
+	if err != nil {
+		return err
+	}
+	p, noSolution, err := ns.SolutionSet()
+	if err != nil {
+		return err
+	}
+	if noSolution {
+		return errNoSolution
+	}
+	return writeParameterization(env.stdout, f.out, ns.Variables, p)
+}
+
+// intersectionJSON is the JSON output of the intersect command.
+type intersectionJSON struct {
+	Kind             string                   `json:"kind"`
+	Dimension        int                      `json:"dimension"`
+	Parameterization *linear.Parameterization `json:"parameterization,omitempty"`
+}
+
+func intersect(env environment, args []string) error {
+	f, ns, err := systemCommand(env, "intersect", args, textFormat, jsonFormat, latexFormat)
+	if err != nil {
+		return err
+	}
+	i, err := linear.Intersect(ns.System...)
+	if err != nil {
+		return err
+	}
+	if i.Kind == linear.NoIntersection {
+		return exitCodeError{code: exitNoSolution, err: errors.New("the hyperplanes don't intersect")}
+	}
+	switch f.out {
+	case jsonFormat:
+		return writeJSON(env.stdout, intersectionJSON{Kind: i.Kind.String(), Dimension: i.Dimension, Parameterization: &i.Parameterization})
+	case textFormat:
+		if _, err := fmt.Fprintf(env.stdout, "%d-dimensional intersection (%v)\n", i.Dimension, i.Kind); err != nil {
+			return err
+		}
+	}
+	return writeParameterization(env.stdout, f.out, ns.Variables, i.Parameterization)
+}
+
+// vectorOperations are the subcommands of the vector command.
+var vectorOperations = map[string]func(v1, v2 linear.Vector, degrees bool, format string, env environment) error{
+	"add": func(v1, v2 linear.Vector, degrees bool, format string, env environment) error {
+		v, err := v1.Add(v2)
+		if err != nil {
+			return err
+		}
+		return writeVector(env.stdout, format, v)
+	},
+	"dot": func(v1, v2 linear.Vector, degrees bool, format string, env environment) error {
+		v, err := v1.DotProduct(v2)
+		if err != nil {
+			return err
+		}
+		return writeNumber(env.stdout, format, v)
+	},
+	"angle": func(v1, v2 linear.Vector, degrees bool, format string, env environment) error {
+		r, err := angleBetween(v1, v2)
+		if err != nil {
+			return err
+		}
+		if degrees {
+			return writeNumber(env.stdout, format, r.Degrees())
+		}
+		return writeNumber(env.stdout, format, float64(r))
+	},
+	"cross": func(v1, v2 linear.Vector, degrees bool, format string, env environment) error {
+		v, err := v1.CrossProduct(v2)
+		if err != nil {
+			return err
+		}
+		return writeVector(env.stdout, format, v)
+	},
+}
+
+// angleBetween returns the angle between the vectors, or an error if either is the zero vector, since
+// Vector.AngleBetween returns NaN in that case.
+func angleBetween(v1, v2 linear.Vector) (linear.Radian, error) {
+	if v1.IsZeroVector() || v2.IsZeroVector() {
+		return 0, errors.New("cannot calculate the angle because the angle to a zero vector is undefined")
+	}
+	return v1.AngleBetween(v2)
+}
+
+func vector(env environment, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("expected an operation: add, dot, angle or cross")
+	}
+	operation, ok := vectorOperations[args[0]]
+	if !ok {
+		return usageErrorf("unknown vector operation %q, use one of: add, dot, angle, cross", args[0])
+	}
+	fs := newFlagSet("vector "+args[0], "<vector> <vector>", env)
+	out := fs.String("out", textFormat, "The format of the output: text, json or latex.")
+	degrees := fs.Bool("degrees", false, "Whether the angle is written in degrees rather than radians.")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if err := checkFormat("out", *out, textFormat, jsonFormat, latexFormat); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageErrorf("expected 2 vectors, e.g. [1,2,3] [4,5,6], but got %d arguments", fs.NArg())
+	}
+	vectors := make([]linear.Vector, 2)
+	for i, arg := range fs.Args() {
+		if err := vectors[i].UnmarshalText([]byte(arg)); err != nil {
+			return usageErrorf("%v", err)
+		}
+	}
+	return operation(vectors[0], vectors[1], *degrees, *out, env)
+}
+
+func quiz(env environment, args []string) error {
+	if len(args) != 1 {
+		return usageErrorf("expected the number of the quiz")
+	}
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usageErrorf("cannot read %q as the number of the quiz", args[0])
+	}
+	runQuiz(env.stdout, number)
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/a-h/linear"
+)
+
+// Formats used to read and write systems of equations and results.
+const (
+	textFormat  = "text"
+	csvFormat   = "csv"
+	jsonFormat  = "json"
+	latexFormat = "latex"
+)
+
+// systemFlags are the flags of the commands which read a system of equations.
+type systemFlags struct {
+	in     string
+	out    string
+	header bool
+}
+
+func newSystemFlags(fs *flag.FlagSet) *systemFlags {
+	f := &systemFlags{}
+	fs.StringVar(&f.in, "in", "", "The format of the input: text, csv or json. Defaults to the file extension, or text.")
+	fs.StringVar(&f.out, "out", textFormat, "The format of the output.")
+	fs.BoolVar(&f.header, "header", false, "Whether CSV input and output has a header row containing the variable names.")
+	return f
+}
+
+// readSystem reads a system of equations from the file named in args, or stdin. Text input can use any variable names,
+// e.g. 2x + 3y = 5, x - y = 1, while CSV input uses the variable names in the header row, if there is one.
+func readSystem(env environment, args []string, f *systemFlags) (linear.NamedSystem, error) {
+	if len(args) > 1 {
+		return linear.NamedSystem{}, usageErrorf("expected a single file, but got %d arguments", len(args))
+	}
+	name, r := "stdin", env.stdin
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return linear.NamedSystem{}, err
+		}
+		defer file.Close()
+		name, r = args[0], file
+	}
+	format := f.in
+	if format == "" {
+		format = textFormat
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".csv":
+			format = csvFormat
+		case ".json":
+			format = jsonFormat
+		}
+	}
+	if err := checkFormat("in", format, textFormat, csvFormat, jsonFormat); err != nil {
+		return linear.NamedSystem{}, err
+	}
+	ns, err := parseSystem(r, format, f.header)
+	if err != nil {
+		return linear.NamedSystem{}, fmt.Errorf("cannot read the system from %s: %v", name, err)
+	}
+	return ns, nil
+}
+
+func parseSystem(r io.Reader, format string, header bool) (linear.NamedSystem, error) {
+	switch format {
+	case csvFormat:
+		s, variables, err := linear.ReadCSV(r, linear.CSVOptions{Header: header})
+		if err != nil {
+			return linear.NamedSystem{}, err
+		}
+		return linear.NamedSystem{Variables: variables, System: s}, nil
+	case jsonFormat:
+		var s linear.System
+		if err := json.NewDecoder(r).Decode(&s); err != nil {
+			return linear.NamedSystem{}, err
+		}
+		if len(s) == 0 {
+			return linear.NamedSystem{}, fmt.Errorf("the system does not contain any equations")
+		}
+		return linear.NewNamedSystem(linear.DefaultVariables(len(s[0].NormalVector)), s...)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return linear.NamedSystem{}, err
+	}
+	return linear.ParseNamedSystem(linear.DetectVariables(string(data)), string(data))
+}
+
+// writeSystem writes the system in the output format, using the variable names.
+func writeSystem(w io.Writer, f *systemFlags, variables linear.Variables, s linear.System) error {
+	switch f.out {
+	case csvFormat:
+		return linear.WriteCSV(w, s, variables, linear.CSVOptions{Header: f.header})
+	case jsonFormat:
+		return writeJSON(w, s)
+	case latexFormat:
+		r := linear.NewLaTeXRenderer()
+		r.Variables = variables
+		_, err := fmt.Fprintln(w, r.System(s))
+		return err
+	}
+	// Write one equation per line, so that the output can be read back in.
+	for _, e := range s {
+		text, err := variables.FormatEquation(e)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeParameterization writes the parameterization in the output format, using the variable names.
+func writeParameterization(w io.Writer, format string, variables linear.Variables, p linear.Parameterization) error {
+	switch format {
+	case jsonFormat:
+		return writeJSON(w, p)
+	case latexFormat:
+		r := linear.NewLaTeXRenderer()
+		r.Variables = variables
+		_, err := fmt.Fprintln(w, r.Parameterization(p))
+		return err
+	}
+	text, err := variables.FormatParameterization(p)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, text)
+	return err
+}
+
+func writeVector(w io.Writer, format string, v linear.Vector) error {
+	switch format {
+	case jsonFormat:
+		return writeJSON(w, v)
+	case latexFormat:
+		_, err := fmt.Fprintln(w, linear.NewLaTeXRenderer().Vector(v))
+		return err
+	}
+	_, err := fmt.Fprintln(w, v)
+	return err
+}
+
+func writeNumber(w io.Writer, format string, v float64) error {
+	switch format {
+	case jsonFormat:
+		return writeJSON(w, v)
+	case latexFormat:
+		_, err := fmt.Fprintln(w, linear.NewLaTeXRenderer().Number(v))
+		return err
+	}
+	_, err := fmt.Fprintln(w, linear.Formatter{}.Number(v))
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunFunction(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "system.csv")
+	if err := os.WriteFile(csvPath, []byte("price,qty,constant\n1,1,10\n1,-1,2\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expected       string
+		expectedCode   int
+		expectedStderr string
+	}{
+		{
+			name:     "solve text",
+			args:     []string{"solve"},
+			stdin:    "x + y = 3, x - y = 1",
+			expected: "x = 2\ny = 1\n",
+		},
+		{
+			name:     "solve a CSV file with a header",
+			args:     []string{"solve", "-header", "-out", "csv", csvPath},
+			expected: "price,qty\n6,4\n",
+		},
+		{
+			name:     "solve CSV writes negative zero as 0",
+			args:     []string{"solve", "-out", "csv"},
+			stdin:    "-x = 0",
+			expected: "0\n",
+		},
+		{
+			name:     "solve JSON",
+			args:     []string{"solve", "-in", "json", "-out", "json", "-"},
+			stdin:    `{"version":1,"equations":[{"version":1,"normalVector":[2],"constantTerm":4}]}`,
+			expected: "[2]\n",
+		},
+		{
+			name:           "no solution",
+			args:           []string{"solve"},
+			stdin:          "x + y = 1; x + y = 2",
+			expectedCode:   exitNoSolution,
+			expectedStderr: "linear: the system has no solution\n",
+		},
+		{
+			name:           "infinite solutions",
+			args:           []string{"solve"},
+			stdin:          "x + y = 1",
+			expectedCode:   exitInfiniteSolutions,
+			expectedStderr: "linear: the system has infinitely many solutions, use parameterize to describe them\n",
+		},
+		{
+			name:     "rref",
+			args:     []string{"rref"},
+			stdin:    "x + y = 3\nx - y = 1",
+			expected: "1x + 0y = 2\n0x + 1y = 1\n",
+		},
+		{
+			name:     "triangular JSON",
+			args:     []string{"triangular", "-out", "json"},
+			stdin:    "x = 1",
+			expected: `{"version":1,"equations":[{"version":1,"normalVector":[1],"constantTerm":1}]}` + "\n",
+		},
+		{
+			name:     "parameterize",
+			args:     []string{"parameterize"},
+			stdin:    "x + y = 1",
+			expected: "{ x = 1 - y, y = y }\n",
+		},
+		{
+			name:     "intersect",
+			args:     []string{"intersect"},
+			stdin:    "x + y = 1",
+			expected: "1-dimensional intersection (affine subspace)\n{ x = 1 - y, y = y }\n",
+		},
+		{
+			name:           "parallel planes don't intersect",
+			args:           []string{"intersect"},
+			stdin:          "x + y = 1; x + y = 2",
+			expectedCode:   exitNoSolution,
+			expectedStderr: "linear: the hyperplanes don't intersect\n",
+		},
+		{
+			name:     "vector add",
+			args:     []string{"vector", "add", "[1, 2]", "[3,4]"},
+			expected: "[4, 6]\n",
+		},
+		{
+			name:     "vector dot",
+			args:     []string{"vector", "dot", "-out", "json", "[1,2]", "[3,4]"},
+			expected: "11\n",
+		},
+		{
+			name:     "vector angle",
+			args:     []string{"vector", "angle", "-degrees", "[1,0]", "[0,1]"},
+			expected: "90\n",
+		},
+		{
+			name:           "vector angle with a zero vector",
+			args:           []string{"vector", "angle", "[0,0]", "[1,0]"},
+			expectedCode:   exitError,
+			expectedStderr: "linear: cannot calculate the angle because the angle to a zero vector is undefined\n",
+		},
+		{
+			name:     "vector cross",
+			args:     []string{"vector", "cross", "[1,0,0]", "[0,1,0]"},
+			expected: "[0, 0, 1]\n",
+		},
+		{
+			name:     "quiz",
+			args:     []string{"quiz", "4"},
+			expected: "Printing answers for quiz 4.\n0: parallel: true, orthogonal: false\n1: parallel: false, orthogonal: false\n2: parallel: false, orthogonal: true\n3: parallel: true, orthogonal: true\n",
+		},
+		{
+			name:     "legacy quiz flag",
+			args:     []string{"-quiz", "4"},
+			expected: "Printing answers for quiz 4.\n0: parallel: true, orthogonal: false\n1: parallel: false, orthogonal: false\n2: parallel: false, orthogonal: true\n3: parallel: true, orthogonal: true\n",
+		},
+		{
+			name:     "quiz not found",
+			args:     []string{"quiz", "99"},
+			expected: "Printing answers for quiz 99.\nQuiz not found.\n",
+		},
+		{
+			name:           "invalid input",
+			args:           []string{"solve"},
+			stdin:          "x + = 1",
+			expectedCode:   exitError,
+			expectedStderr: "linear: cannot read the system from stdin: expected a term at position 5\n",
+		},
+		{
+			name:           "unknown command",
+			args:           []string{"invert"},
+			expectedCode:   exitUsage,
+			expectedStderr: "linear: unknown command \"invert\"\nRun \"linear help\" for usage.\n",
+		},
+		{
+			name:           "unsupported output format",
+			args:           []string{"parameterize", "-out", "csv"},
+			expectedCode:   exitUsage,
+			expectedStderr: "linear: the -out format \"csv\" is not supported, use one of: text, json, latex\nRun \"linear help\" for usage.\n",
+		},
+		{
+			name:           "wrong number of vectors",
+			args:           []string{"vector", "add", "[1]"},
+			expectedCode:   exitUsage,
+			expectedStderr: "linear: expected 2 vectors, e.g. [1,2,3] [4,5,6], but got 1 arguments\nRun \"linear help\" for usage.\n",
+		},
+	}
+
+	for _, test := range tests {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		code := run(test.args, environment{stdin: strings.NewReader(test.stdin), stdout: stdout, stderr: stderr})
+		if code != test.expectedCode {
+			t.Errorf("%s: expected exit code %d, but got %d (%s)", test.name, test.expectedCode, code, stderr.String())
+		}
+		if stdout.String() != test.expected {
+			t.Errorf("%s: expected output %q, but got %q", test.name, test.expected, stdout.String())
+		}
+		if stderr.String() != test.expectedStderr {
+			t.Errorf("%s: expected error output %q, but got %q", test.name, test.expectedStderr, stderr.String())
+		}
+	}
+}
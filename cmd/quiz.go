@@ -0,0 +1,442 @@
+package main
+
+import "fmt"
+import "io"
+import "github.com/a-h/linear"
+import "github.com/a-h/round"
+
+// runQuiz writes the answers to one of the quizzes from the Udacity Linear Algebra Refresher course, see
+// https://www.udacity.com/course/linear-algebra-refresher-course--ud953
+func runQuiz(w io.Writer, quiz int) {
+	fmt.Fprintf(w, "Printing answers for quiz %d.\n", quiz)
+
+	switch quiz {
+	case 1:
+		quiz1(w)
+	case 2:
+		quiz2(w)
+	case 3:
+		quiz3(w)
+	case 4:
+		quiz4(w)
+	case 5:
+		quiz5(w)
+	case 6:
+		quiz6(w)
+	case 7:
+		quiz7(w)
+	case 8:
+		quiz8(w)
+	case 9:
+		quiz9(w)
+	case 10:
+		quiz10(w)
+	case 11:
+		quiz11(w)
+	case 12:
+		quiz12(w)
+	case 13:
+		quiz13(w)
+	default:
+		fmt.Fprintln(w, "Quiz not found.")
+	}
+}
+
+func quiz1(w io.Writer) {
+	q1, err := linear.NewVector(8.218, -9.341).Add(linear.NewVector(-1.129, 2.111))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 1")
+		return
+	}
+	fmt.Fprintln(w, q1)
+	q2, err := linear.NewVector(7.119, 8.215).Sub(linear.NewVector(-8.223, 0.878))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 2")
+		return
+	}
+	fmt.Fprintln(w, q2)
+	q3 := linear.NewVector(1.671, -1.012, -0.318).Scale(7.41)
+	fmt.Fprintln(w, q3)
+}
+
+func quiz2(w io.Writer) {
+	q1 := linear.NewVector(-0.221, 7.437).Magnitude()
+	fmt.Fprintln(w, q1)
+	q2 := linear.NewVector(8.813, -1.331, -6.247).Magnitude()
+	fmt.Fprintln(w, q2)
+	q3 := linear.NewVector(5.581, -2.136).Normalize()
+	fmt.Fprintln(w, q3)
+	q4 := linear.NewVector(1.996, 3.108, -4.554).Normalize()
+	fmt.Fprintln(w, q4)
+}
+
+func quiz3(w io.Writer) {
+	q1, err := linear.NewVector(7.887, 4.138).DotProduct(linear.NewVector(-8.802, 6.776))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 1")
+		return
+	}
+	fmt.Fprintln(w, q1)
+	q2, err := linear.NewVector(-5.955, -4.904, -1.874).DotProduct(linear.NewVector(-4.496, -8.755, 7.103))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 2")
+		return
+	}
+	fmt.Fprintln(w, q2)
+	q3, err := linear.NewVector(3.183, -7.627).AngleBetween(linear.NewVector(-2.668, 5.319))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 3")
+		return
+	}
+	fmt.Fprintln(w, q3)
+	q4, err := linear.NewVector(7.35, 0.221, 5.188).AngleBetween(linear.NewVector(2.751, 8.259, 3.985))
+	if err != nil {
+		fmt.Fprintln(w, "Failed to answer question 4")
+		return
+	}
+	fmt.Fprintln(w, q4.Degrees())
+}
+
+func quiz4(w io.Writer) {
+	questions := []struct {
+		v linear.Vector
+		w linear.Vector
+	}{
+		{
+			v: linear.NewVector(-7.579, -7.88),
+			w: linear.NewVector(22.737, 23.64),
+		},
+		{
+			v: linear.NewVector(-2.029, 9.97, 4.172),
+			w: linear.NewVector(-9.231, -6.639, -7.245),
+		},
+		{
+			v: linear.NewVector(-2.328, -7.284, -1.214),
+			w: linear.NewVector(-1.821, 1.072, -2.94),
+		},
+		{
+			v: linear.NewVector(2.118, 4.827),
+			w: linear.NewVector(0, 0),
+		},
+	}
+
+	for i, q := range questions {
+		isParallel, err := q.v.IsParallelTo(q.w)
+		if err != nil {
+			fmt.Fprintf(w, "Failed to answer question %d (is parallel) with err %v", i, err)
+			return
+		}
+
+		isOrthogonal, err := q.v.IsOrthogonalTo(q.w)
+		if err != nil {
+			fmt.Fprintf(w, "Failed to answer question %d (is orthogonal) with err %v", i, err)
+			return
+		}
+
+		fmt.Fprintf(w, "%d: parallel: %v, orthogonal: %v\n", i, isParallel, isOrthogonal)
+	}
+}
+
+func quiz5(w io.Writer) { // Coding vector projections
+	questions := []struct {
+		v linear.Vector
+		b linear.Vector // the basis vector
+	}{
+		{
+			v: linear.NewVector(3.039, 1.879),
+			b: linear.NewVector(0.825, 2.036),
+		},
+		{
+			v: linear.NewVector(-9.88, -3.264, -8.159),
+			b: linear.NewVector(-2.155, -9.353, -9.473),
+		},
+		{
+			v: linear.NewVector(3.009, -6.172, 3.692, -2.51),
+			b: linear.NewVector(6.404, -9.144, 2.759, 8.718),
+		},
+	}
+
+	for i, q := range questions {
+		projection, err := q.b.Projection(q.v)
+		if err != nil {
+			fmt.Fprintf(w, "Failed to calculate the projection for question %d with err %v", i, err)
+			return
+		}
+
+		orhogonal, err := q.b.ProjectionOrthogonalComponent(q.v)
+		if err != nil {
+			fmt.Fprintf(w, "Failed to calculate the orthogonal for question %d with err %v", i, err)
+			return
+		}
+
+		fmt.Fprintf(w, "%d: projection: %v, orthogonal: %v\n", i, projection.Round(3), orhogonal.Round(3))
+	}
+}
+
+func quiz6(w io.Writer) { // Coding cross products
+	a, err := linear.NewVector(8.462, 7.893, -8.187).CrossProduct(linear.NewVector(6.984, -5.975, 4.778))
+	if err != nil {
+		fmt.Fprintf(w, "Failed to calculate the cross product for question a with err %v", err)
+		return
+	}
+	fmt.Fprintln(w, "a: ", a.Round(3))
+
+	b, err := linear.NewVector(-8.987, -9.838, 5.031).AreaOfParallelogram(linear.NewVector(-4.268, -1.861, -8.866))
+	if err != nil {
+		fmt.Fprintf(w, "Failed to calculate the cross product for question b with err %v", err)
+		return
+	}
+	fmt.Fprintln(w, "b: ", round.ToEven(b, 3))
+
+	c, err := linear.NewVector(1.5, 9.547, 3.691).AreaOfTriangle(linear.NewVector(-6.007, 0.124, 5.772))
+	if err != nil {
+		fmt.Fprintf(w, "Failed to calculate the cross product for question c with err %v", err)
+		return
+	}
+	fmt.Fprintln(w, "c: ", round.ToEven(c, 3))
+}
+
+func quiz7(w io.Writer) { // Insections of lines
+	questions := []struct {
+		a linear.Equation
+		b linear.Equation
+	}{
+		{
+			a: linear.NewEquation(linear.NewVector(4.046, 2.836), 1.21),
+			b: linear.NewEquation(linear.NewVector(10.115, 7.09), 3.025),
+		},
+		{
+			a: linear.NewEquation(linear.NewVector(7.204, 3.182), 8.68),
+			b: linear.NewEquation(linear.NewVector(8.172, 4.114), 9.883),
+		},
+		{
+			a: linear.NewEquation(linear.NewVector(1.182, 5.562), 6.744),
+			b: linear.NewEquation(linear.NewVector(1.773, 8.343), 9.525),
+		},
+	}
+
+	for i, q := range questions {
+		v, intersects, equal, err := q.a.IntersectionWith(q.b)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+
+		fmt.Fprintf(w, "%d: ", i)
+		if intersects {
+			fmt.Fprintf(w, "%v ", v.Round(3))
+		} else {
+			fmt.Fprintf(w, "no intersection ")
+		}
+
+		fmt.Fprintf(w, "equal: %v\n", equal)
+	}
+}
+
+func quiz8(w io.Writer) { // Parallel and equal planes
+	questions := []struct {
+		a linear.Equation
+		b linear.Equation
+	}{
+		{
+			a: linear.NewEquation(linear.NewVector(-0.412, 3.806, 0.728), -3.46),
+			b: linear.NewEquation(linear.NewVector(1.03, -9.515, -1.82), 8.65),
+		},
+		{
+			a: linear.NewEquation(linear.NewVector(2.611, 5.528, 0.283), 4.6),
+			b: linear.NewEquation(linear.NewVector(7.715, 8.306, 5.342), 3.76),
+		},
+		{
+			a: linear.NewEquation(linear.NewVector(-7.926, 8.625, -7.212), -7.952),
+			b: linear.NewEquation(linear.NewVector(-2.642, 2.875, -2.404), -2.443),
+		},
+	}
+
+	for i, q := range questions {
+		equal, err := q.a.Eq(q.b)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+
+		parallel, err := q.a.IsParallelTo(q.b)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+
+		fmt.Fprintf(w, "%d: ", i)
+		fmt.Fprintf(w, "equal: %v,  parallel: %v\n", equal, parallel)
+	}
+}
+
+func quiz9(w io.Writer) { // Coding row operations
+	// Converted the Python code from Udacity to match the Go I've written.
+	p0 := linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p1 := linear.NewEquation(linear.NewVector(0, 1, 0), 2)
+	p2 := linear.NewEquation(linear.NewVector(1, 1, -1), 3)
+	p3 := linear.NewEquation(linear.NewVector(1, 0, -2), 2)
+
+	s := linear.NewSystem(p0, p1, p2, p3)
+
+	// Tests
+	s = test(w, 1, func() (linear.System, error) { return s.Swap(0, 1) }, linear.NewSystem(p1, p0, p2, p3))
+	s = test(w, 2, func() (linear.System, error) { return s.Swap(1, 3) }, linear.NewSystem(p1, p3, p2, p0))
+	s = test(w, 3, func() (linear.System, error) { return s.Swap(3, 1) }, linear.NewSystem(p1, p0, p2, p3))
+	s = test(w, 4, func() (linear.System, error) { return s.Multiply(0, 1) }, linear.NewSystem(p1, p0, p2, p3))
+	p2_2 := linear.NewEquation(linear.NewVector(-1, -1, 1), -3)
+	s = test(w, 5, func() (linear.System, error) { return s.Multiply(2, -1) }, linear.NewSystem(p1, p0, p2_2, p3))
+	p1_2 := linear.NewEquation(linear.NewVector(10, 10, 10), 10)
+	s = test(w, 6, func() (linear.System, error) { return s.Multiply(1, 10) }, linear.NewSystem(p1, p1_2, p2_2, p3))
+	s = test(w, 7, func() (linear.System, error) { return s.Add(0, 1, 0) }, linear.NewSystem(p1, p1_2, p2_2, p3))
+	p1_3 := linear.NewEquation(linear.NewVector(10, 11, 10), 12)
+	s = test(w, 8, func() (linear.System, error) { return s.Add(0, 1, 1) }, linear.NewSystem(p1, p1_3, p2_2, p3))
+	p0_1 := linear.NewEquation(linear.NewVector(-10, -10, -10), -10)
+	s = test(w, 9, func() (linear.System, error) { return s.Add(1, 0, -1) }, linear.NewSystem(p0_1, p1_3, p2_2, p3))
+	fmt.Fprintln(w, "quiz 9 complete...")
+}
+
+func test(w io.Writer, number int, operation func() (linear.System, error), expected linear.System) linear.System {
+	actual, err := operation()
+	if err != nil {
+		fmt.Fprintf(w, "test case %d failed with err: %v\n", number, err)
+	}
+	eq, err := actual.Eq(expected)
+	if !eq {
+		fmt.Fprintf(w, "test case %d failed expected %v, but got %v\n", number, expected, actual)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "test case %d failed to compare with %v\n", number, err)
+	}
+	return actual
+}
+
+func quiz10(w io.Writer) { // Triangular form
+	p1 := linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 := linear.NewEquation(linear.NewVector(0, 1, 1), 2)
+	s := linear.NewSystem(p1, p2)
+	test(w, 1, func() (linear.System, error) { return s.TriangularForm() }, linear.NewSystem(p1, p2))
+
+	p1 = linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(1, 1, 1), 2)
+	s = linear.NewSystem(p1, p2)
+	test(w, 2, func() (linear.System, error) { return s.TriangularForm() }, linear.NewSystem(p1, linear.NewEquation(linear.NewVector(0, 0, 0), 1)))
+
+	p1 = linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(0, 1, 0), 2)
+	p3 := linear.NewEquation(linear.NewVector(1, 1, -1), 3)
+	p4 := linear.NewEquation(linear.NewVector(1, 0, -2), 2)
+	s = linear.NewSystem(p1, p2, p3, p4)
+	expected := linear.NewSystem(p1, p2, linear.NewEquation(linear.NewVector(0, 0, -2), 2), linear.NewEquation(linear.NewVector(), 0))
+	test(w, 3, func() (linear.System, error) { return s.TriangularForm() }, expected)
+
+	p1 = linear.NewEquation(linear.NewVector(0, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(1, -1, 1), 2)
+	p3 = linear.NewEquation(linear.NewVector(1, 2, -5), 3)
+	s = linear.NewSystem(p1, p2, p3)
+	expected = linear.NewSystem(linear.NewEquation(linear.NewVector(1, -1, 1), 2),
+		linear.NewEquation(linear.NewVector(0, 1, 1), 1),
+		linear.NewEquation(linear.NewVector(0, 0, -9), -2))
+	test(w, 4, func() (linear.System, error) { return s.TriangularForm() }, expected)
+}
+
+func quiz11(w io.Writer) { // Coding RREF.
+	p1 := linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 := linear.NewEquation(linear.NewVector(0, 1, 1), 2)
+	s := linear.NewSystem(p1, p2)
+	expected := linear.NewSystem(linear.NewEquation(linear.NewVector(1, 0, 0), -1), p2)
+	test(w, 1, func() (linear.System, error) { r, _, err := s.ComputeRREF(); return r, err }, expected)
+
+	p1 = linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(1, 1, 1), 2)
+	s = linear.NewSystem(p1, p2)
+	expected = linear.NewSystem(p1, linear.NewEquation(linear.NewVector(0, 0, 0), 1))
+	test(w, 2, func() (linear.System, error) { r, _, err := s.ComputeRREF(); return r, err }, expected)
+
+	p1 = linear.NewEquation(linear.NewVector(1, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(0, 1, 0), 2)
+	p3 := linear.NewEquation(linear.NewVector(1, 1, -1), 3)
+	p4 := linear.NewEquation(linear.NewVector(1, 0, -2), 2)
+	s = linear.NewSystem(p1, p2, p3, p4)
+	// See https://discussions.udacity.com/t/coding-rref-test-case-3/204986
+	expected = linear.NewSystem(linear.NewEquation(linear.NewVector(1, 0, 0), 0), p2,
+		linear.NewEquation(linear.NewVector(0, 0, 1), -1),
+		linear.NewEquation(linear.NewVector(0, 0, 0), 0))
+	test(w, 3, func() (linear.System, error) { r, _, err := s.ComputeRREF(); return r, err }, expected)
+
+	p1 = linear.NewEquation(linear.NewVector(0, 1, 1), 1)
+	p2 = linear.NewEquation(linear.NewVector(1, -1, 1), 2)
+	p3 = linear.NewEquation(linear.NewVector(1, 2, -5), 3)
+	s = linear.NewSystem(p1, p2, p3)
+	expected = linear.NewSystem(linear.NewEquation(linear.NewVector(1, 0, 0), 23.0/9.0),
+		linear.NewEquation(linear.NewVector(0, 1, 0), 7.0/9.0),
+		linear.NewEquation(linear.NewVector(0, 0, 1), 2.0/9.0))
+	test(w, 4, func() (linear.System, error) { r, _, err := s.ComputeRREF(); return r, err }, expected)
+}
+
+func quiz12(w io.Writer) { // Coding GE Solution
+	p1 := linear.NewEquation(linear.NewVector(5.862, 1.178, -10.366), -8.15)
+	p2 := linear.NewEquation(linear.NewVector(-2.931, -0.589, 5.183), -4.075)
+	s := linear.NewSystem(p1, p2)
+	solution, noSolution, infiniteSolutions, _ := s.Solve()
+	fmt.Fprintf(w, "q1: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+
+	p1 = linear.NewEquation(linear.NewVector(8.631, 5.112, -1.816), -5.113)
+	p2 = linear.NewEquation(linear.NewVector(4.315, 11.132, -5.27), -6.775)
+	p3 := linear.NewEquation(linear.NewVector(-2.158, 3.01, -1.727), -0.831)
+	s = linear.NewSystem(p1, p2, p3)
+	solution, noSolution, infiniteSolutions, _ = s.Solve()
+	fmt.Fprintf(w, "q2: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+
+	p1 = linear.NewEquation(linear.NewVector(5.262, 2.739, -9.878), -3.441)
+	p2 = linear.NewEquation(linear.NewVector(5.111, 6.358, 7.638), -2.152)
+	p3 = linear.NewEquation(linear.NewVector(2.016, -9.924, -1.367), -9.278)
+	p4 := linear.NewEquation(linear.NewVector(2.167, -13.543, -18.883), -10.567)
+	s = linear.NewSystem(p1, p2, p3, p4)
+	solution, noSolution, infiniteSolutions, _ = s.Solve()
+	fmt.Fprintf(w, "q4: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+}
+
+func quiz13(w io.Writer) { // Coding Parameterization
+	// Q1
+	// There appears to be a bug in the tutorial code here, see answer at:
+	// https://github.com/omarrayward/Linear-Algebra-Refresher-Udacity/blob/master/linear_system.py
+	system := linear.NewSystem(
+		linear.NewEquation(linear.NewVector(0.786, 0.786, 0.588), -0.714),
+		linear.NewEquation(linear.NewVector(-0.131, -0.131, 0.244), 0.319)) // The tutorial shows -0.138, not -0.131
+
+	solution, noSolution, infiniteSolutions, _ := system.Solve()
+	fmt.Fprintf(w, "q1: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+	if infiniteSolutions {
+		s, _, _ := system.ComputeRREF()
+		p, err := s.Parameterize()
+		fmt.Fprintln(w, p, err)
+	}
+
+	// Q2
+	system = linear.NewSystem(
+		linear.NewEquation(linear.NewVector(8.631, 5.112, -1.816), -5.113),
+		linear.NewEquation(linear.NewVector(4.315, 11.132, -5.27), -6.775),
+		linear.NewEquation(linear.NewVector(-2.158, 3.01, -1.727), -0.831))
+	solution, noSolution, infiniteSolutions, _ = system.Solve()
+	fmt.Fprintf(w, "q2: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+	if infiniteSolutions {
+		s, _, _ := system.ComputeRREF()
+		p, err := s.Parameterize()
+		fmt.Fprintln(w, p, err)
+	}
+
+	// Q3
+	system = linear.NewSystem(
+		linear.NewEquation(linear.NewVector(0.935, 1.76, -9.365), -9.955),
+		linear.NewEquation(linear.NewVector(0.187, 0.352, -1.873), -1.991),
+		linear.NewEquation(linear.NewVector(0.374, 0.704, -3.746), -3.982),
+		linear.NewEquation(linear.NewVector(-0.561, -1.056, 5.619), 5.973))
+	solution, noSolution, infiniteSolutions, _ = system.Solve()
+	fmt.Fprintf(w, "q3: Solution: %v No Solution: %v Infinite Solutions: %v\n", solution, noSolution, infiniteSolutions)
+	if infiniteSolutions {
+		s, _, _ := system.ComputeRREF()
+		p, _ := s.Parameterize()
+		fmt.Fprintln(w, p)
+	}
+}
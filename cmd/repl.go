@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/a-h/linear"
+)
+
+const replHelp = `Define vectors and systems of equations:
+  v = [1, 2, 3]
+  S = { 2x + 3y = 5, x - y = 1 }
+  w = add v [1,1,1]
+
+Operations on vectors: add, sub, dot, angle, cross, magnitude, normalize
+Operations on systems: solve, rref, triangular, parameterize, intersect
+Arguments are names or vectors written without spaces, e.g. dot v [1,0,0], or a system, e.g. solve { x = 1 }
+
+Other commands:
+  <name>       show the value of a name
+  vars         list every name
+  history      list the lines entered in this session
+  save <file>  save every name to a session file
+  load <file>  run the lines of a session file
+  help         show this help
+  exit         leave the shell
+`
+
+// replCommands are the words which can't be used as names.
+var replCommands = map[string]bool{
+	"vars": true, "history": true, "save": true, "load": true, "help": true, "exit": true, "quit": true,
+}
+
+// repl is an interactive shell which stores vectors and systems of equations by name.
+type repl struct {
+	// values contains a linear.Vector or linear.NamedSystem for each name.
+	values  map[string]interface{}
+	history []string
+	out     io.Writer
+}
+
+func newREPL(out io.Writer) *repl {
+	return &repl{values: map[string]interface{}{}, out: out}
+}
+
+func replCommand(env environment, args []string) error {
+	fs := newFlagSet("repl", "", env)
+	load := fs.String("load", "", "A session file to load at the start.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	r := newREPL(env.stdout)
+	if *load != "" {
+		if err := r.load(*load); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(env.stdout, `Type "help" for help.`)
+	return r.run(env.stdin)
+}
+
+// run reads and executes lines until the input ends or the exit command is entered.
+func (r *repl) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(r.out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(r.out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		r.history = append(r.history, line)
+		exit, err := r.execute(line)
+		if err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+		if exit {
+			return nil
+		}
+	}
+}
+
+// execute runs a single line, returning true if the shell should exit.
+func (r *repl) execute(line string) (exit bool, err error) {
+	if strings.HasPrefix(line, "#") {
+		return false, nil
+	}
+	if name, expression, ok := strings.Cut(line, "="); ok && isREPLName(strings.TrimSpace(name)) {
+		return false, r.assign(strings.TrimSpace(name), strings.TrimSpace(expression))
+	}
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "exit", "quit":
+		return true, nil
+	case "help":
+		fmt.Fprint(r.out, replHelp)
+		return false, nil
+	case "history":
+		for i, h := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, h)
+		}
+		return false, nil
+	case "vars":
+		for _, name := range r.names() {
+			fmt.Fprintf(r.out, "%s = %s\n", name, formatREPLValue(r.values[name]))
+		}
+		return false, nil
+	case "save", "load":
+		if len(fields) != 2 {
+			return false, fmt.Errorf("expected a file name, e.g. %s session.txt", fields[0])
+		}
+		if fields[0] == "save" {
+			return false, r.save(fields[1])
+		}
+		return false, r.load(fields[1])
+	}
+	v, err := r.evaluate(line)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintln(r.out, formatREPLValue(v))
+	return false, nil
+}
+
+func isREPLName(s string) bool {
+	if replCommands[s] || replOperations[s] != nil {
+		return false
+	}
+	_, err := linear.NewVariables(s)
+	return err == nil
+}
+
+func (r *repl) names() []string {
+	names := make([]string, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// assign stores the vector, system or the result of an operation under the name.
+func (r *repl) assign(name string, expression string) error {
+	v, err := r.evaluate(expression)
+	if err != nil {
+		return err
+	}
+	switch v.(type) {
+	case linear.Vector, linear.NamedSystem:
+		r.values[name] = v
+		return nil
+	}
+	return fmt.Errorf("only vectors and systems can be stored, but %q is %s", expression, formatREPLValue(v))
+}
+
+// evaluate returns the value of a name, a vector, a system, or the result of an operation.
+func (r *repl) evaluate(expression string) (interface{}, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 0 {
+		return nil, errors.New("expected a value")
+	}
+	switch {
+	case strings.HasPrefix(expression, "["):
+		return parseREPLVector(expression)
+	case strings.HasPrefix(expression, "{"):
+		return parseSystem(strings.NewReader(expression), textFormat, false)
+	}
+	operation, ok := replOperations[fields[0]]
+	if !ok {
+		if len(fields) == 1 {
+			return r.lookup(fields[0])
+		}
+		return nil, fmt.Errorf("unknown operation %q", fields[0])
+	}
+	// A system can be written after the operation, e.g. solve { x + y = 3, x - y = 1 }
+	if rest := strings.TrimSpace(strings.TrimPrefix(expression, fields[0])); strings.HasPrefix(rest, "{") {
+		ns, err := parseSystem(strings.NewReader(rest), textFormat, false)
+		if err != nil {
+			return nil, err
+		}
+		return operation([]interface{}{ns})
+	}
+	args := make([]interface{}, len(fields)-1)
+	for i, field := range fields[1:] {
+		var err error
+		if args[i], err = r.lookup(field); err != nil {
+			return nil, err
+		}
+	}
+	return operation(args)
+}
+
+func (r *repl) lookup(arg string) (interface{}, error) {
+	if strings.HasPrefix(arg, "[") {
+		return parseREPLVector(arg)
+	}
+	v, ok := r.values[arg]
+	if !ok {
+		return nil, fmt.Errorf("%q is not defined", arg)
+	}
+	return v, nil
+}
+
+func parseREPLVector(s string) (linear.Vector, error) {
+	var v linear.Vector
+	err := v.UnmarshalText([]byte(s))
+	return v, err
+}
+
+// save writes an assignment for each name, which can be read back with load.
+func (r *repl) save(path string) error {
+	var sb strings.Builder
+	for _, name := range r.names() {
+		fmt.Fprintf(&sb, "%s = %s\n", name, formatREPLValue(r.values[name]))
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "saved %d names to %s\n", len(r.values), path)
+	return nil
+}
+
+// load runs each line of a session file, stopping at the first error.
+func (r *repl) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if _, err := r.execute(line); err != nil {
+			return fmt.Errorf("%s:%d: %v", path, i+1, err)
+		}
+	}
+	return nil
+}
+
+func formatREPLValue(v interface{}) string {
+	switch v := v.(type) {
+	case linear.Vector:
+		return v.String()
+	case linear.NamedSystem:
+		return v.String()
+	case float64:
+		return linear.Formatter{}.Number(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+// replOperations are the operations which can be called in the shell. Each returns a linear.Vector,
+// linear.NamedSystem, float64 or string.
+var replOperations = map[string]func(args []interface{}) (interface{}, error){
+	"add": func(args []interface{}) (interface{}, error) {
+		v1, v2, err := twoVectors("add", args)
+		if err != nil {
+			return nil, err
+		}
+		return v1.Add(v2)
+	},
+	"sub": func(args []interface{}) (interface{}, error) {
+		v1, v2, err := twoVectors("sub", args)
+		if err != nil {
+			return nil, err
+		}
+		return v1.Sub(v2)
+	},
+	"dot": func(args []interface{}) (interface{}, error) {
+		v1, v2, err := twoVectors("dot", args)
+		if err != nil {
+			return nil, err
+		}
+		return v1.DotProduct(v2)
+	},
+	"angle": func(args []interface{}) (interface{}, error) {
+		v1, v2, err := twoVectors("angle", args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := v1.AngleBetween(v2)
+		return float64(r), err
+	},
+	"cross": func(args []interface{}) (interface{}, error) {
+		v1, v2, err := twoVectors("cross", args)
+		if err != nil {
+			return nil, err
+		}
+		return v1.CrossProduct(v2)
+	},
+	"magnitude": func(args []interface{}) (interface{}, error) {
+		v, err := oneVector("magnitude", args)
+		if err != nil {
+			return nil, err
+		}
+		return v.Magnitude(), nil
+	},
+	"normalize": func(args []interface{}) (interface{}, error) {
+		v, err := oneVector("normalize", args)
+		if err != nil {
+			return nil, err
+		}
+		return v.Normalize(), nil
+	},
+	"solve": func(args []interface{}) (interface{}, error) {
+		ns, err := oneSystem("solve", args)
+		if err != nil {
+			return nil, err
+		}
+		solution, noSolution, infiniteSolutions, err := ns.System.Solve()
+		if err != nil {
+			return nil, err
+		}
+		if noSolution {
+			return nil, errNoSolution
+		}
+		if infiniteSolutions {
+			return nil, errInfiniteSolutions
+		}
+		return solution, nil
+	},
+	"rref": func(args []interface{}) (interface{}, error) {
+		ns, err := oneSystem("rref", args)
+		if err != nil {
+			return nil, err
+		}
+		s, _, err := ns.System.ComputeRREF()
+		return linear.NamedSystem{Variables: ns.Variables, System: s}, err
+	},
+	"triangular": func(args []interface{}) (interface{}, error) {
+		ns, err := oneSystem("triangular", args)
+		if err != nil {
+			return nil, err
+		}
+		s, err := ns.System.TriangularForm()
+		return linear.NamedSystem{Variables: ns.Variables, System: s}, err
+	},
+	"parameterize": func(args []interface{}) (interface{}, error) {
+		ns, err := oneSystem("parameterize", args)
+		if err != nil {
+			return nil, err
+		}
+		p, noSolution, err := ns.SolutionSet()
+		if err != nil {
+			return nil, err
+		}
+		if noSolution {
+			return nil, errNoSolution
+		}
+		return ns.Variables.FormatParameterization(p)
+	},
+	"intersect": func(args []interface{}) (interface{}, error) {
+		ns, err := oneSystem("intersect", args)
+		if err != nil {
+			return nil, err
+		}
+		i, err := linear.Intersect(ns.System...)
+		if err != nil || i.Kind == linear.NoIntersection {
+			return "no intersection", err
+		}
+		p, err := ns.Variables.FormatParameterization(i.Parameterization)
+		return fmt.Sprintf("%d-dimensional intersection (%v) %s", i.Dimension, i.Kind, p), err
+	},
+}
+
+func oneVector(operation string, args []interface{}) (linear.Vector, error) {
+	if len(args) == 1 {
+		if v, ok := args[0].(linear.Vector); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%s expects a vector, e.g. %s v", operation, operation)
+}
+
+func twoVectors(operation string, args []interface{}) (linear.Vector, linear.Vector, error) {
+	if len(args) == 2 {
+		v1, ok1 := args[0].(linear.Vector)
+		v2, ok2 := args[1].(linear.Vector)
+		if ok1 && ok2 {
+			return v1, v2, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%s expects 2 vectors, e.g. %s v w", operation, operation)
+}
+
+func oneSystem(operation string, args []interface{}) (linear.NamedSystem, error) {
+	if len(args) == 1 {
+		if ns, ok := args[0].(linear.NamedSystem); ok {
+			return ns, nil
+		}
+	}
+	return linear.NamedSystem{}, fmt.Errorf("%s expects a system, e.g. %s S", operation, operation)
+}
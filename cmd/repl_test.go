@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestREPLExecuteFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected string
+	}{
+		{
+			name:     "vector operations",
+			lines:    []string{"v = [1,2,3]", "w = [0, 1, 0]", "dot v w", "u = add v w", "u", "cross v [0,0,1]"},
+			expected: "2\n[1, 3, 3]\n[2, -1, 0]\n",
+		},
+		{
+			name:     "system operations",
+			lines:    []string{"S = { x + y = 3, x - y = 1 }", "solve S", "rref S", "parameterize { x + y = 1 }"},
+			expected: "[2, 1]\n{ 1x + 0y = 2, 0x + 1y = 1 }\n{ x = 1 - y, y = y }\n",
+		},
+		{
+			name:     "errors",
+			lines:    []string{"dot v w", "v = [1]", "dot v", "solve { x + y = 1 }", "S = magnitude v", "invert v"},
+			expected: "error: \"v\" is not defined\nerror: dot expects 2 vectors, e.g. dot v w\nerror: the system has infinitely many solutions, use parameterize to describe them\nerror: only vectors and systems can be stored, but \"magnitude v\" is 1\nerror: unknown operation \"invert\"\n",
+		},
+		{
+			name:     "vars and history",
+			lines:    []string{"b = [2]", "a = { x = 1 }", "vars", "history"},
+			expected: "a = { 1x = 1 }\nb = [2]\n   1  b = [2]\n   2  a = { x = 1 }\n   3  vars\n   4  history\n",
+		},
+		{
+			name:     "exit",
+			lines:    []string{"exit", "help"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		out := new(bytes.Buffer)
+		r := newREPL(out)
+		if err := r.run(strings.NewReader(strings.Join(test.lines, "\n"))); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		// Remove the prompts, so that only the results are compared.
+		actual := strings.ReplaceAll(out.String(), "> ", "")
+		if strings.Trim(actual, "\n") != strings.Trim(test.expected, "\n") {
+			t.Errorf("%s: expected %q, but got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestREPLSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.txt")
+
+	r := newREPL(new(bytes.Buffer))
+	for _, line := range []string{"v = [1, -2.5]", "S = { 2price + qty = 5, price - qty = 1 }", "save " + path} {
+		if _, err := r.execute(line); err != nil {
+			t.Fatalf("%s: unexpected error: %v", line, err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "S = { 2price + 1qty = 5, 1price - 1qty = 1 }\nv = [1, -2.5]\n"
+	if string(data) != expected {
+		t.Errorf("expected the session file %q, but got %q", expected, data)
+	}
+
+	out := new(bytes.Buffer)
+	loaded := newREPL(out)
+	if _, err := loaded.execute("load " + path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := loaded.execute("solve S"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "[2, 1]\n" {
+		t.Errorf("expected [2, 1], but got %q", out.String())
+	}
+
+	if err := os.WriteFile(path, []byte("v = [1]\nw = [\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = newREPL(out).execute("load " + path)
+	if err == nil || !strings.HasSuffix(err.Error(), "session.txt:2: cannot read vector \"[\", it must be surrounded by square brackets") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a-h/linear"
+)
+
+// serverOptions limit the resources used by each request to the HTTP API.
+type serverOptions struct {
+	// maxBodySize is the largest request body in bytes.
+	maxBodySize int64
+	// maxCoefficients is the largest number of coefficients in a system of equations.
+	maxCoefficients int
+	// timeout is the longest time a request can take before a 503 response is returned.
+	timeout time.Duration
+}
+
+var defaultServerOptions = serverOptions{
+	maxBodySize:     1 << 20,
+	maxCoefficients: 10000,
+	timeout:         10 * time.Second,
+}
+
+func serve(env environment, args []string) error {
+	fs := newFlagSet("serve", "", env)
+	addr := fs.String("addr", "localhost:8080", "The address to listen on.")
+	options := defaultServerOptions
+	fs.Int64Var(&options.maxBodySize, "max-body-size", options.maxBodySize, "The largest request body in bytes.")
+	fs.IntVar(&options.maxCoefficients, "max-coefficients", options.maxCoefficients, "The largest number of coefficients in a system of equations.")
+	fs.DurationVar(&options.timeout, "timeout", options.timeout, "The longest time a request can take.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newAPIHandler(options),
+		ReadHeaderTimeout: options.timeout,
+		ReadTimeout:       options.timeout,
+		// Allow time for the timeout response to be written.
+		WriteTimeout: options.timeout + time.Second,
+	}
+	fmt.Fprintf(env.stderr, "listening on %s\n", *addr)
+	return server.ListenAndServe()
+}
+
+// apiError is the error response of the HTTP API, e.g.
+// {"error":{"code":"no_solution","message":"the system has no solution"}}
+type apiError struct {
+	status  int
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e apiError) Error() string {
+	return e.Message
+}
+
+func newAPIError(status int, code string, format string, a ...interface{}) apiError {
+	return apiError{status: status, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// newAPIHandler creates the HTTP API. Every endpoint accepts a POST request containing JSON in the format written by
+// the library's MarshalJSON functions, and responds with JSON.
+//
+//	POST /vector/{add,sub,dot,angle,cross} {"a":[1,2,3],"b":[4,5,6]}
+//	POST /solve, /rref, /triangular, /parameterize, /intersect {"version":1,"equations":[...]}
+func newAPIHandler(options serverOptions) http.Handler {
+	mux := http.NewServeMux()
+	for name, f := range apiVectorOperations {
+		mux.Handle("/vector/"+name, options.endpoint(vectorEndpoint(options, f)))
+	}
+	mux.Handle("/solve", options.endpoint(systemEndpoint(options, apiSolve)))
+	mux.Handle("/rref", options.endpoint(systemEndpoint(options, apiRREF)))
+	mux.Handle("/triangular", options.endpoint(systemEndpoint(options, apiTriangular)))
+	mux.Handle("/parameterize", options.endpoint(systemEndpoint(options, apiParameterize)))
+	mux.Handle("/intersect", options.endpoint(systemEndpoint(options, apiIntersect)))
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, newAPIError(http.StatusNotFound, "not_found", "there is no endpoint at %s", r.URL.Path))
+	}))
+	timeoutBody, _ := json.Marshal(map[string]apiError{"error": options.timeoutError()})
+	h := http.TimeoutHandler(mux, options.timeout, string(timeoutBody))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The timeout handler writes its body without a Content-Type, so set it here. Every response is JSON.
+		w.Header().Set("Content-Type", "application/json")
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (o serverOptions) timeoutError() apiError {
+	return newAPIError(http.StatusServiceUnavailable, "timeout", "the request took longer than %v", o.timeout)
+}
+
+// checkTimeout returns an error if the request has timed out, so that no more work is done for a response which won't
+// be written.
+func (o serverOptions) checkTimeout(r *http.Request) error {
+	if r.Context().Err() != nil {
+		return o.timeoutError()
+	}
+	return nil
+}
+
+// endpoint handles a POST request with a JSON body, writing the result of f as JSON.
+func (o serverOptions) endpoint(f func(r *http.Request) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAPIError(w, newAPIError(http.StatusMethodNotAllowed, "method_not_allowed", "the %s method is not allowed, use POST", r.Method))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, o.maxBodySize)
+		result, err := f(r)
+		if err != nil {
+			var ae apiError
+			if !errors.As(err, &ae) {
+				ae = newAPIError(http.StatusUnprocessableEntity, "invalid_input", "%v", err)
+			}
+			writeAPIError(w, ae)
+			return
+		}
+		data, err := json.Marshal(result)
+		var uve *json.UnsupportedValueError
+		if errors.As(err, &uve) {
+			// JSON can't represent infinite or NaN values, which are caused by the input, e.g. a system which overflows.
+			writeAPIError(w, newAPIError(http.StatusUnprocessableEntity, "invalid_input", "the result contains infinite or NaN values, which can't be written as JSON"))
+			return
+		}
+		if err != nil {
+			writeAPIError(w, newAPIError(http.StatusInternalServerError, "internal_error", "cannot write the response: %v", err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(append(data, '\n'))
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, e apiError) {
+	data, _ := json.Marshal(map[string]apiError{"error": e})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	w.Write(append(data, '\n'))
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		return newAPIError(http.StatusRequestEntityTooLarge, "request_too_large", "the request body is larger than %d bytes", mbe.Limit)
+	}
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "invalid_json", "cannot read the request body: %v", err)
+	}
+	return nil
+}
+
+// vectorRequest is the request body of the /vector endpoints.
+type vectorRequest struct {
+	A linear.Vector `json:"a"`
+	B linear.Vector `json:"b"`
+}
+
+// resultResponse is the response of the endpoints which don't return a library type.
+type resultResponse struct {
+	Result interface{} `json:"result"`
+}
+
+var apiVectorOperations = map[string]func(a, b linear.Vector) (interface{}, error){
+	"add": func(a, b linear.Vector) (interface{}, error) { return a.Add(b) },
+	"sub": func(a, b linear.Vector) (interface{}, error) { return a.Sub(b) },
+	"dot": func(a, b linear.Vector) (interface{}, error) { return a.DotProduct(b) },
+	"angle": func(a, b linear.Vector) (interface{}, error) {
+		r, err := angleBetween(a, b)
+		return r, err
+	},
+	"cross": func(a, b linear.Vector) (interface{}, error) { return a.CrossProduct(b) },
+}
+
+func vectorEndpoint(options serverOptions, f func(a, b linear.Vector) (interface{}, error)) func(r *http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var req vectorRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		if len(req.A)+len(req.B) > options.maxCoefficients {
+			return nil, newAPIError(http.StatusRequestEntityTooLarge, "request_too_large", "the vectors contain more than %d values", options.maxCoefficients)
+		}
+		if err := options.checkTimeout(r); err != nil {
+			return nil, err
+		}
+		result, err := f(req.A, req.B)
+		if err != nil {
+			return nil, err
+		}
+		return resultResponse{Result: result}, nil
+	}
+}
+
+func systemEndpoint(options serverOptions, f func(s linear.System) (interface{}, error)) func(r *http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var s linear.System
+		if err := decode(r, &s); err != nil {
+			return nil, err
+		}
+		if len(s) == 0 {
+			return nil, newAPIError(http.StatusBadRequest, "invalid_input", "the system does not contain any equations")
+		}
+		coefficients := 0
+		for _, e := range s {
+			coefficients += len(e.NormalVector)
+		}
+		if coefficients > options.maxCoefficients {
+			return nil, newAPIError(http.StatusRequestEntityTooLarge, "request_too_large", "the system contains more than %d coefficients", options.maxCoefficients)
+		}
+		if err := options.checkTimeout(r); err != nil {
+			return nil, err
+		}
+		return f(s)
+	}
+}
+
+var (
+	apiNoSolution        = newAPIError(http.StatusUnprocessableEntity, "no_solution", "the system has no solution")
+	apiInfiniteSolutions = newAPIError(http.StatusUnprocessableEntity, "infinite_solutions", "the system has infinitely many solutions, use /parameterize to describe them")
+)
+
+func apiSolve(s linear.System) (interface{}, error) {
+	solution, noSolution, infiniteSolutions, err := s.Solve()
+	if err != nil {
+		return nil, err
+	}
+	if noSolution {
+		return nil, apiNoSolution
+	}
+	if infiniteSolutions {
+		return nil, apiInfiniteSolutions
+	}
+	return resultResponse{Result: solution}, nil
+}
+
+func apiRREF(s linear.System) (interface{}, error) {
+	rref, _, err := s.ComputeRREF()
+	return rref, err
+}
+
+func apiTriangular(s linear.System) (interface{}, error) {
+	return s.TriangularForm()
+}
+
+func apiParameterize(s linear.System) (interface{}, error) {
+	p, noSolution, err := s.SolutionSet()
+	if err != nil {
+		return nil, err
+	}
+	if noSolution {
+		return nil, apiNoSolution
+	}
+	return p, nil
+}
+
+func apiIntersect(s linear.System) (interface{}, error) {
+	i, err := linear.Intersect(s...)
+	if err != nil {
+		return nil, err
+	}
+	response := intersectionJSON{Kind: i.Kind.String(), Dimension: i.Dimension}
+	if i.Kind != linear.NoIntersection {
+		response.Parameterization = &i.Parameterization
+	}
+	return response, nil
+}
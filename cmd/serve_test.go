@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a-h/linear"
+)
+
+func TestAPIHandler(t *testing.T) {
+	server := httptest.NewServer(newAPIHandler(serverOptions{
+		maxBodySize:     256,
+		maxCoefficients: 6,
+		timeout:         time.Second,
+	}))
+	defer server.Close()
+
+	const system = `{"version":1,"equations":[{"version":1,"normalVector":[1,1],"constantTerm":3},{"version":1,"normalVector":[1,-1],"constantTerm":1}]}`
+	const line = `{"version":1,"equations":[{"version":1,"normalVector":[1,1],"constantTerm":1}]}`
+	const parallel = `{"version":1,"equations":[{"version":1,"normalVector":[1,1],"constantTerm":1},{"version":1,"normalVector":[1,1],"constantTerm":2}]}`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			name:           "vector add",
+			path:           "/vector/add",
+			body:           `{"a":[1,2],"b":[3,4]}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"result":[4,6]}`,
+		},
+		{
+			name:           "vector dot",
+			path:           "/vector/dot",
+			body:           `{"a":[1,2],"b":[3,4]}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"result":11}`,
+		},
+		{
+			name:           "vector cross",
+			path:           "/vector/cross",
+			body:           `{"a":[1,0,0],"b":[0,1,0]}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"result":[0,0,1]}`,
+		},
+		{
+			name:           "solve",
+			path:           "/solve",
+			body:           system,
+			expectedStatus: http.StatusOK,
+			expected:       `{"result":[2,1]}`,
+		},
+		{
+			name:           "rref",
+			path:           "/rref",
+			body:           system,
+			expectedStatus: http.StatusOK,
+			expected:       `{"version":1,"equations":[{"version":1,"normalVector":[1,0],"constantTerm":2},{"version":1,"normalVector":[-0,1],"constantTerm":1}]}`,
+		},
+		{
+			name:           "parameterize",
+			path:           "/parameterize",
+			body:           line,
+			expectedStatus: http.StatusOK,
+			expected:       `{"version":1,"basepoint":[1,0],"directionVectors":[[-1,1]]}`,
+		},
+		{
+			name:           "intersect",
+			path:           "/intersect",
+			body:           system,
+			expectedStatus: http.StatusOK,
+			expected:       `{"kind":"point","dimension":0,"parameterization":{"version":1,"basepoint":[2,1],"directionVectors":[]}}`,
+		},
+		{
+			name:           "no intersection",
+			path:           "/intersect",
+			body:           parallel,
+			expectedStatus: http.StatusOK,
+			expected:       `{"kind":"none","dimension":-1}`,
+		},
+		{
+			name:           "no solution",
+			path:           "/solve",
+			body:           parallel,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected:       `{"error":{"code":"no_solution","message":"the system has no solution"}}`,
+		},
+		{
+			name:           "infinite solutions",
+			path:           "/solve",
+			body:           line,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected:       `{"error":{"code":"infinite_solutions","message":"the system has infinitely many solutions, use /parameterize to describe them"}}`,
+		},
+		{
+			name:           "vectors with different dimensions",
+			path:           "/vector/add",
+			body:           `{"a":[1,2],"b":[3]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected:       `{"error":{"code":"invalid_input","message":"cannot add vectors together because they have different dimensions (2 and 1)"}}`,
+		},
+		{
+			name:           "angle to a zero vector",
+			path:           "/vector/angle",
+			body:           `{"a":[0,0],"b":[1,0]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected:       `{"error":{"code":"invalid_input","message":"cannot calculate the angle because the angle to a zero vector is undefined"}}`,
+		},
+		{
+			name:           "solution which overflows",
+			path:           "/solve",
+			body:           `{"version":1,"equations":[{"version":1,"normalVector":[1,1],"constantTerm":1e308},{"version":1,"normalVector":[1,-1],"constantTerm":-1e308}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected:       `{"error":{"code":"invalid_input","message":"the result contains infinite or NaN values, which can't be written as JSON"}}`,
+		},
+		{
+			name:           "invalid JSON",
+			path:           "/solve",
+			body:           `{"version":`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"error":{"code":"invalid_json","message":"cannot read the request body: unexpected EOF"}}`,
+		},
+		{
+			name:           "unsupported schema version",
+			path:           "/rref",
+			body:           `{"version":2,"equations":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"error":{"code":"invalid_json","message":"cannot read the request body: cannot read system with schema version 2, the supported versions are 1 to 1"}}`,
+		},
+		{
+			name:           "empty system",
+			path:           "/solve",
+			body:           `{"version":1,"equations":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"error":{"code":"invalid_input","message":"the system does not contain any equations"}}`,
+		},
+		{
+			name:           "body too large",
+			path:           "/vector/add",
+			body:           `{"a":[` + strings.Repeat("1,", 200) + `1],"b":[1]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       `{"error":{"code":"request_too_large","message":"the request body is larger than 256 bytes"}}`,
+		},
+		{
+			name:           "too many coefficients",
+			path:           "/solve",
+			body:           `{"version":1,"equations":[{"version":1,"normalVector":[1,2,3,4,5,6,7],"constantTerm":1}]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       `{"error":{"code":"request_too_large","message":"the system contains more than 6 coefficients"}}`,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/solve",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expected:       `{"error":{"code":"method_not_allowed","message":"the GET method is not allowed, use POST"}}`,
+		},
+		{
+			name:           "unknown endpoint",
+			path:           "/invert",
+			body:           system,
+			expectedStatus: http.StatusNotFound,
+			expected:       `{"error":{"code":"not_found","message":"there is no endpoint at /invert"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		method := test.method
+		if method == "" {
+			method = http.MethodPost
+		}
+		req, err := http.NewRequest(method, server.URL+test.path, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != test.expectedStatus {
+			t.Errorf("%s: expected status %d, but got %d", test.name, test.expectedStatus, resp.StatusCode)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("%s: expected a JSON response, but got %q", test.name, contentType)
+		}
+		if actual := strings.TrimSpace(string(body)); actual != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestAPIHandlerTimeout(t *testing.T) {
+	options := serverOptions{maxBodySize: 256, maxCoefficients: 6, timeout: time.Millisecond}
+	// A request body which never ends causes the request to time out while it is read.
+	body, w := io.Pipe()
+	defer w.Close()
+	req := httptest.NewRequest(http.MethodPost, "/solve", body)
+	rec := httptest.NewRecorder()
+	newAPIHandler(options).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, but got %q", ct)
+	}
+	expected := `{"error":{"code":"timeout","message":"the request took longer than 1ms"}}`
+	if rec.Body.String() != expected {
+		t.Errorf("expected %s, but got %s", expected, rec.Body.String())
+	}
+}
+
+func TestAPIEndpointsStopWhenTheRequestHasTimedOut(t *testing.T) {
+	options := serverOptions{maxBodySize: 256, maxCoefficients: 6, timeout: time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		endpoint func(r *http.Request) (interface{}, error)
+		body     string
+	}{
+		{
+			name: "system",
+			endpoint: systemEndpoint(options, func(s linear.System) (interface{}, error) {
+				t.Error("system: expected the operation not to run after the request timed out")
+				return nil, nil
+			}),
+			body: `{"version":1,"equations":[{"version":1,"normalVector":[1],"constantTerm":1}]}`,
+		},
+		{
+			name: "vector",
+			endpoint: vectorEndpoint(options, func(a, b linear.Vector) (interface{}, error) {
+				t.Error("vector: expected the operation not to run after the request timed out")
+				return nil, nil
+			}),
+			body: `{"a":[1],"b":[2]}`,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body)).WithContext(ctx)
+		_, err := test.endpoint(req)
+		var ae apiError
+		if !errors.As(err, &ae) || ae.Code != "timeout" {
+			t.Errorf("%s: expected a timeout error, but got %v", test.name, err)
+		}
+	}
+}
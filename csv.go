@@ -0,0 +1,232 @@
+package linear
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// constantTermHeader is the name written in the header row above the column of constant terms.
+const constantTermHeader = "constant"
+
+// csvNumber writes numbers with as many digits as are required to read them back exactly, and writes negative zero,
+// which is often produced by elimination, as 0.
+var csvNumber = Formatter{Verb: 'g', Precision: -1}
+
+// CSVOptions configures how systems of equations are read from and written to CSV files.
+type CSVOptions struct {
+	// Comma is the field delimiter. If it is zero, a comma is used.
+	Comma rune
+	// Comment, if not zero, is a character which marks lines to ignore when reading.
+	Comment rune
+	// Header determines whether the first row contains the names of the variables, followed by a name for the column of
+	// constant terms, e.g. price,qty,constant.
+	Header bool
+}
+
+// CSVReader reads a system of equations from a CSV file in augmented matrix form, where each row is an equation, each
+// column except the last contains the coefficients of a variable, and the last column contains the constant terms.
+// Rows are read one at a time, so large files don't need to fit in memory.
+type CSVReader struct {
+	r         *csv.Reader
+	options   CSVOptions
+	variables Variables
+	// fields is the number of fields in each row, or zero if it isn't known yet.
+	fields     int
+	headerRead bool
+	// headerErr is the error from reading the header row, which is returned by every later call.
+	headerErr error
+}
+
+// NewCSVReader creates a reader which reads equations from r.
+func NewCSVReader(r io.Reader, options CSVOptions) *CSVReader {
+	cr := csv.NewReader(r)
+	if options.Comma != 0 {
+		cr.Comma = options.Comma
+	}
+	cr.Comment = options.Comment
+	// The number of fields is checked when reading each row, to provide a better error message.
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+	return &CSVReader{r: cr, options: options}
+}
+
+// Variables returns the names of the variables from the header row. If the options don't include a header, the
+// variables are named x₁, x₂, and so on, once the first row has been read.
+func (cr *CSVReader) Variables() (Variables, error) {
+	if err := cr.readHeader(); err != nil {
+		return nil, err
+	}
+	return cr.variables, nil
+}
+
+func (cr *CSVReader) readHeader() error {
+	if cr.headerRead || !cr.options.Header {
+		return cr.headerErr
+	}
+	cr.headerRead = true
+	cr.headerErr = cr.parseHeader()
+	return cr.headerErr
+}
+
+func (cr *CSVReader) parseHeader() error {
+	record, err := cr.r.Read()
+	if err == io.EOF {
+		return errors.New("the CSV file is empty, but a header row was expected")
+	}
+	if err != nil {
+		return err
+	}
+	if len(record) < 2 {
+		return fmt.Errorf("line %d: the header row must contain at least one variable name and the constant term column", cr.line(0))
+	}
+	names := make([]string, len(record)-1)
+	for i, name := range record[:len(record)-1] {
+		names[i] = strings.TrimSpace(name)
+	}
+	cr.variables, err = NewVariables(names...)
+	if err != nil {
+		return fmt.Errorf("line %d: %v", cr.line(0), err)
+	}
+	cr.fields = len(record)
+	return nil
+}
+
+func (cr *CSVReader) line(field int) int {
+	line, _ := cr.r.FieldPos(field)
+	return line
+}
+
+// Read reads the next equation. It returns io.EOF when there are no more equations.
+func (cr *CSVReader) Read() (Equation, error) {
+	if err := cr.readHeader(); err != nil {
+		return Equation{}, err
+	}
+	record, err := cr.r.Read()
+	if err != nil {
+		return Equation{}, err
+	}
+	if cr.fields == 0 {
+		if len(record) < 2 {
+			return Equation{}, fmt.Errorf("line %d: each row must contain at least one coefficient and the constant term", cr.line(0))
+		}
+		cr.fields = len(record)
+		cr.variables = DefaultVariables(cr.fields - 1)
+	}
+	if len(record) != cr.fields {
+		return Equation{}, fmt.Errorf("line %d: expected %d fields, but found %d", cr.line(0), cr.fields, len(record))
+	}
+	values := make(Vector, len(record))
+	for i, field := range record {
+		values[i], err = strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			line, column := cr.r.FieldPos(i)
+			return Equation{}, fmt.Errorf("line %d, column %d: cannot read %q as a number", line, column, field)
+		}
+	}
+	return NewEquation(values[:len(values)-1], values[len(values)-1]), nil
+}
+
+// ReadAll reads every remaining equation.
+func (cr *CSVReader) ReadAll() (System, error) {
+	op := System{}
+	for {
+		e, err := cr.Read()
+		if err == io.EOF {
+			return op, nil
+		}
+		if err != nil {
+			return System{}, err
+		}
+		op = append(op, e)
+	}
+}
+
+// ReadCSV reads a system of equations from a CSV file in augmented matrix form, returning the system and the names of
+// its variables.
+func ReadCSV(r io.Reader, options CSVOptions) (System, Variables, error) {
+	cr := NewCSVReader(r, options)
+	s, err := cr.ReadAll()
+	if err != nil {
+		return System{}, nil, err
+	}
+	return s, cr.variables, nil
+}
+
+// CSVWriter writes a system of equations to a CSV file in augmented matrix form, one equation at a time.
+type CSVWriter struct {
+	w       *csv.Writer
+	options CSVOptions
+	// fields is the number of fields in each row, or zero if it isn't known yet.
+	fields int
+}
+
+// NewCSVWriter creates a writer which writes equations to w. Call Flush when all of the equations have been written.
+func NewCSVWriter(w io.Writer, options CSVOptions) *CSVWriter {
+	cw := csv.NewWriter(w)
+	if options.Comma != 0 {
+		cw.Comma = options.Comma
+	}
+	return &CSVWriter{w: cw, options: options}
+}
+
+// WriteHeader writes the header row, containing the names of the variables followed by the name of the constant term
+// column. It must be called before any equations are written.
+func (cw *CSVWriter) WriteHeader(variables Variables) error {
+	if cw.fields != 0 {
+		return errors.New("the header must be written before any equations")
+	}
+	if len(variables) == 0 {
+		return errors.New("the header must contain at least one variable")
+	}
+	cw.fields = len(variables) + 1
+	return cw.w.Write(append(append([]string{}, variables...), constantTermHeader))
+}
+
+// Write writes a single equation. Every equation must have the same number of coefficients.
+func (cw *CSVWriter) Write(e Equation) error {
+	if cw.fields == 0 {
+		if cw.options.Header {
+			if err := cw.WriteHeader(DefaultVariables(len(e.NormalVector))); err != nil {
+				return err
+			}
+		}
+		cw.fields = len(e.NormalVector) + 1
+	}
+	if len(e.NormalVector)+1 != cw.fields {
+		return fmt.Errorf("cannot write an equation with %d coefficients to a file with %d coefficients in each row", len(e.NormalVector), cw.fields-1)
+	}
+	record := make([]string, 0, cw.fields)
+	for _, coefficient := range e.NormalVector {
+		record = append(record, csvNumber.Number(coefficient))
+	}
+	record = append(record, csvNumber.Number(e.ConstantTerm))
+	return cw.w.Write(record)
+}
+
+// Flush writes any buffered data to the underlying writer, and returns any error which occurred while writing.
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// WriteCSV writes the system of equations to a CSV file in augmented matrix form. If the options include a header,
+// the variables are used to name the columns, or x₁, x₂, and so on if variables is nil.
+func WriteCSV(w io.Writer, s System, variables Variables, options CSVOptions) error {
+	cw := NewCSVWriter(w, options)
+	if options.Header && variables != nil {
+		if err := cw.WriteHeader(variables); err != nil {
+			return err
+		}
+	}
+	for _, e := range s {
+		if err := cw.Write(e); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
@@ -0,0 +1,196 @@
+package linear
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                string
+		options              CSVOptions
+		expected             System
+		expectedVariables    Variables
+		expectedErrorMessage string
+	}{
+		{
+			name:  "without a header",
+			input: "1,2,3\n4,5,6\n",
+			expected: NewSystem(
+				NewEquation(NewVector(1, 2), 3),
+				NewEquation(NewVector(4, 5), 6),
+			),
+			expectedVariables: Variables{"x₁", "x₂"},
+		},
+		{
+			name:    "with a header",
+			input:   "price, qty, constant\n1, -1, 2\n1, 1, 10\n",
+			options: CSVOptions{Header: true},
+			expected: NewSystem(
+				NewEquation(NewVector(1, -1), 2),
+				NewEquation(NewVector(1, 1), 10),
+			),
+			expectedVariables: Variables{"price", "qty"},
+		},
+		{
+			name:    "semicolon delimiter with comments",
+			input:   "# the first equation\n1.5;2e-3;-3\n",
+			options: CSVOptions{Comma: ';', Comment: '#'},
+			expected: NewSystem(
+				NewEquation(NewVector(1.5, 0.002), -3),
+			),
+			expectedVariables: Variables{"x₁", "x₂"},
+		},
+		{
+			name:                 "invalid number",
+			input:                "1,2,3\n4,abc,6\n",
+			expectedErrorMessage: "line 2, column 3: cannot read \"abc\" as a number",
+		},
+		{
+			name:                 "wrong number of fields",
+			input:                "1,2,3\n4,5\n",
+			expectedErrorMessage: "line 2: expected 3 fields, but found 2",
+		},
+		{
+			name:                 "header doesn't match the rows",
+			input:                "a,b,constant\n1,2\n",
+			options:              CSVOptions{Header: true},
+			expectedErrorMessage: "line 2: expected 3 fields, but found 2",
+		},
+		{
+			name:                 "invalid variable name",
+			input:                "a,a,constant\n1,2,3\n",
+			options:              CSVOptions{Header: true},
+			expectedErrorMessage: "line 1: the variable name \"a\" is used more than once",
+		},
+		{
+			name:                 "missing header",
+			input:                "",
+			options:              CSVOptions{Header: true},
+			expectedErrorMessage: "the CSV file is empty, but a header row was expected",
+		},
+		{
+			name:                 "no coefficients",
+			input:                "1\n",
+			expectedErrorMessage: "line 1: each row must contain at least one coefficient and the constant term",
+		},
+	}
+
+	for _, test := range tests {
+		actual, variables, err := ReadCSV(strings.NewReader(test.input), test.options)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if eq, _ := actual.Eq(test.expected); !eq {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+		if strings.Join(variables, ",") != strings.Join(test.expectedVariables, ",") {
+			t.Errorf("%s: expected variables %v, but got %v", test.name, test.expectedVariables, variables)
+		}
+	}
+}
+
+func TestCSVReaderReadsOneRowAtATime(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("1,2,3\n4,5,6\nbad,row,here\n"), CSVOptions{})
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("row %d: unexpected error: %v", i+1, err)
+		}
+	}
+	if _, err := r.Read(); err == nil || err.Error() != "line 3, column 1: cannot read \"bad\" as a number" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	empty := NewCSVReader(strings.NewReader(""), CSVOptions{})
+	if _, err := empty.Read(); err != io.EOF {
+		t.Errorf("expected io.EOF, but got %v", err)
+	}
+}
+
+func TestCSVReaderHeaderErrorIsReturnedByEveryRead(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("x,x,constant\n1,2,3\n"), CSVOptions{Header: true})
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(); err == nil || err.Error() != "line 1: the variable name \"x\" is used more than once" {
+			t.Errorf("read %d: expected the header error, but got %v", i+1, err)
+		}
+	}
+	if _, err := r.Variables(); err == nil {
+		t.Error("expected the header error from Variables, but got nil")
+	}
+}
+
+func TestWriteCSVFunction(t *testing.T) {
+	s := NewSystem(
+		NewEquation(NewVector(1, -0.5), 3),
+		NewEquation(NewVector(1e-7, 5), 6),
+	)
+	vs, _ := NewVariables("price", "qty")
+
+	tests := []struct {
+		name      string
+		variables Variables
+		options   CSVOptions
+		expected  string
+	}{
+		{
+			name:     "without a header",
+			expected: "1,-0.5,3\n1e-07,5,6\n",
+		},
+		{
+			name:      "with a header",
+			variables: vs,
+			options:   CSVOptions{Header: true},
+			expected:  "price,qty,constant\n1,-0.5,3\n1e-07,5,6\n",
+		},
+		{
+			name:     "with a default header",
+			options:  CSVOptions{Header: true, Comma: '\t'},
+			expected: "x₁\tx₂\tconstant\n1\t-0.5\t3\n1e-07\t5\t6\n",
+		},
+	}
+
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		if err := WriteCSV(buf, s, test.variables, test.options); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected %q, but got %q", test.name, test.expected, buf.String())
+		}
+
+		// Reading the output gives back the same system.
+		actual, _, err := ReadCSV(buf, test.options)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading the output: %v", test.name, err)
+			continue
+		}
+		if eq, _ := actual.Eq(s); !eq {
+			t.Errorf("%s: expected %v, but got %v", test.name, s, actual)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WriteCSV(buf, NewSystem(NewEquation(NewVector(math.Copysign(0, -1), 1), math.Copysign(0, -1))), nil, CSVOptions{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if buf.String() != "0,1,0\n" {
+		t.Errorf("expected negative zero to be written as 0, but got %q", buf.String())
+	}
+
+	err := WriteCSV(new(bytes.Buffer), NewSystem(NewEquation(NewVector(1, 2), 3), NewEquation(NewVector(1), 3)), nil, CSVOptions{})
+	if err == nil || err.Error() != "cannot write an equation with 1 coefficients to a file with 2 coefficients in each row" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,596 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EncodingVersion is the version of the schema written by the JSON and binary marshalling functions. Data written
+// with a later version of the schema is rejected when it is read.
+//
+// Version 1 of the JSON schema is:
+//
+//	Vector:           [1, 2, 3]
+//	Radian:           1.5707963267948966
+//	Equation:         {"version": 1, "normalVector": [1, 2], "constantTerm": 3}
+//	System:           {"version": 1, "equations": [<Equation>, ...]}
+//	Parameterization: {"version": 1, "basepoint": [1, 0], "directionVectors": [[-1, 1], ...]}
+//
+// Vectors and radians are written as plain JSON arrays and numbers, so that they can be embedded in other documents.
+//
+// The text format is the same as the String output, e.g. [1, 2, 3] for a Vector, 1x₁ + 2x₂ = 3 for an Equation and
+// { 1x₁ + 2x₂ = 3, 4x₁ + 5x₂ = 6 } for a System. A Parameterization is written as its basepoint followed by each
+// direction vector multiplied by a free variable, e.g. [1, 0] + t[-1, 1]. A Radian is written as a number. Numbers are
+// written with as many digits as are required to read them back exactly. Vectors can contain +Inf, -Inf and NaN,
+// but equations and systems which contain them can't be written as text.
+//
+// Version 1 of the binary format starts with a byte containing the version, followed by a byte identifying the type
+// ('V' for Vector, 'R' for Radian, 'E' for Equation, 'S' for System and 'P' for Parameterization). Counts are written
+// as unsigned varints, and numbers as 8 byte big endian IEEE 754 values:
+//
+//	Vector:           count, values
+//	Radian:           value
+//	Equation:         count, normal vector values, constant term
+//	System:           number of equations, then count, normal vector values and constant term for each equation
+//	Parameterization: count, basepoint values, number of direction vectors, then count and values for each one
+const EncodingVersion = 1
+
+const (
+	binaryVector           byte = 'V'
+	binaryRadian           byte = 'R'
+	binaryEquation         byte = 'E'
+	binarySystem           byte = 'S'
+	binaryParameterization byte = 'P'
+)
+
+func checkVersion(version int, name string) error {
+	if version < 1 || version > EncodingVersion {
+		return fmt.Errorf("cannot read %s with schema version %d, the supported versions are 1 to %d", name, version, EncodingVersion)
+	}
+	return nil
+}
+
+// MarshalJSON writes the vector as an array of numbers.
+func (v1 Vector) MarshalJSON() ([]byte, error) {
+	if v1 == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]float64(v1))
+}
+
+// UnmarshalJSON reads the vector from an array of numbers.
+func (v1 *Vector) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("cannot read vector: %v", err)
+	}
+	*v1 = Vector(values)
+	return nil
+}
+
+// MarshalText writes the vector in the same format as String, e.g. [1, 2, 3]
+func (v1 Vector) MarshalText() ([]byte, error) {
+	return []byte(v1.String()), nil
+}
+
+// UnmarshalText reads the vector from the format written by String, e.g. [1, 2, 3]
+func (v1 *Vector) UnmarshalText(text []byte) error {
+	v, err := parseVector(string(text))
+	if err != nil {
+		return err
+	}
+	*v1 = v
+	return nil
+}
+
+func parseVector(s string) (Vector, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return Vector{}, fmt.Errorf("cannot read vector %q, it must be surrounded by square brackets", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return Vector{}, nil
+	}
+	parts := strings.Split(s, ",")
+	op := make(Vector, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return Vector{}, fmt.Errorf("cannot read value %d of vector %q: %v", i+1, s, err)
+		}
+		op[i] = value
+	}
+	return op, nil
+}
+
+// MarshalBinary writes the vector using the binary format described by EncodingVersion.
+func (v1 Vector) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter(binaryVector)
+	w.vector(v1)
+	return w.Bytes(), nil
+}
+
+// UnmarshalBinary reads the vector from the binary format described by EncodingVersion.
+func (v1 *Vector) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data, binaryVector, "vector")
+	if err != nil {
+		return err
+	}
+	v := r.vector()
+	if err := r.close(); err != nil {
+		return err
+	}
+	*v1 = v
+	return nil
+}
+
+// MarshalJSON writes the angle as a number.
+func (r Radian) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(r))
+}
+
+// UnmarshalJSON reads the angle from a number.
+func (r *Radian) UnmarshalJSON(data []byte) error {
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("cannot read radian: %v", err)
+	}
+	*r = Radian(value)
+	return nil
+}
+
+// MarshalText writes the angle as a number.
+func (r Radian) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(r), 'g', -1, 64)), nil
+}
+
+// UnmarshalText reads the angle from a number.
+func (r *Radian) UnmarshalText(text []byte) error {
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(text)), 64)
+	if err != nil {
+		return fmt.Errorf("cannot read radian: %v", err)
+	}
+	*r = Radian(value)
+	return nil
+}
+
+// MarshalBinary writes the angle using the binary format described by EncodingVersion.
+func (r Radian) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter(binaryRadian)
+	w.float(float64(r))
+	return w.Bytes(), nil
+}
+
+// UnmarshalBinary reads the angle from the binary format described by EncodingVersion.
+func (r *Radian) UnmarshalBinary(data []byte) error {
+	br, err := newBinaryReader(data, binaryRadian, "radian")
+	if err != nil {
+		return err
+	}
+	value := br.float()
+	if err := br.close(); err != nil {
+		return err
+	}
+	*r = Radian(value)
+	return nil
+}
+
+type equationJSON struct {
+	Version      int     `json:"version"`
+	NormalVector Vector  `json:"normalVector"`
+	ConstantTerm float64 `json:"constantTerm"`
+}
+
+// MarshalJSON writes the equation using the JSON schema described by EncodingVersion.
+func (l1 Equation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(equationJSON{
+		Version:      EncodingVersion,
+		NormalVector: l1.NormalVector,
+		ConstantTerm: l1.ConstantTerm,
+	})
+}
+
+// UnmarshalJSON reads the equation from the JSON schema described by EncodingVersion.
+func (l1 *Equation) UnmarshalJSON(data []byte) error {
+	var e equationJSON
+	if err := json.Unmarshal(data, &e); err != nil {
+		return fmt.Errorf("cannot read equation: %v", err)
+	}
+	if err := checkVersion(e.Version, "equation"); err != nil {
+		return err
+	}
+	*l1 = NewEquation(e.NormalVector, e.ConstantTerm)
+	return nil
+}
+
+// MarshalText writes the equation in the same format as String, e.g. 1x₁ + 2x₂ = 3. Equations which contain infinite
+// or NaN values can't be written, because the text format can't represent them.
+func (l1 Equation) MarshalText() ([]byte, error) {
+	if err := checkFiniteText(l1, "equation"); err != nil {
+		return nil, err
+	}
+	return []byte(l1.String()), nil
+}
+
+// checkFiniteText returns an error if the equation contains a value which can't be read back from the text format.
+func checkFiniteText(e Equation, name string) error {
+	for i, value := range e.NormalVector {
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return fmt.Errorf("cannot write %s as text, coefficient %d is %v", name, i+1, value)
+		}
+	}
+	if math.IsInf(e.ConstantTerm, 0) || math.IsNaN(e.ConstantTerm) {
+		return fmt.Errorf("cannot write %s as text, the constant term is %v", name, e.ConstantTerm)
+	}
+	return nil
+}
+
+// UnmarshalText reads the equation from the format written by String, e.g. 1x₁ + 2x₂ = 3
+func (l1 *Equation) UnmarshalText(text []byte) error {
+	s := string(text)
+	e, err := DefaultVariables(defaultVariableCount(s)).ParseEquation(s)
+	if err != nil {
+		return fmt.Errorf("cannot read equation: %v", err)
+	}
+	*l1 = e
+	return nil
+}
+
+var defaultVariablePattern = regexp.MustCompile(`x([₀-₉]+)`)
+
+// defaultVariableCount finds the number of variables used in text written by String, from the largest subscript of
+// the default variable names, e.g. 3 for 1x₁ + 0x₂ + 2x₃ = 4.
+func defaultVariableCount(s string) int {
+	count := 0
+	for _, match := range defaultVariablePattern.FindAllStringSubmatch(s, -1) {
+		digits := strings.Map(func(r rune) rune { return r - '₀' + '0' }, match[1])
+		if i, err := strconv.Atoi(digits); err == nil && i > count {
+			count = i
+		}
+	}
+	return count
+}
+
+// MarshalBinary writes the equation using the binary format described by EncodingVersion.
+func (l1 Equation) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter(binaryEquation)
+	w.equation(l1)
+	return w.Bytes(), nil
+}
+
+// UnmarshalBinary reads the equation from the binary format described by EncodingVersion.
+func (l1 *Equation) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data, binaryEquation, "equation")
+	if err != nil {
+		return err
+	}
+	e := r.equation()
+	if err := r.close(); err != nil {
+		return err
+	}
+	*l1 = e
+	return nil
+}
+
+type systemJSON struct {
+	Version   int        `json:"version"`
+	Equations []Equation `json:"equations"`
+}
+
+// MarshalJSON writes the system using the JSON schema described by EncodingVersion.
+func (s1 System) MarshalJSON() ([]byte, error) {
+	equations := []Equation(s1)
+	if equations == nil {
+		equations = []Equation{}
+	}
+	return json.Marshal(systemJSON{
+		Version:   EncodingVersion,
+		Equations: equations,
+	})
+}
+
+// UnmarshalJSON reads the system from the JSON schema described by EncodingVersion.
+func (s1 *System) UnmarshalJSON(data []byte) error {
+	var s systemJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("cannot read system: %v", err)
+	}
+	if err := checkVersion(s.Version, "system"); err != nil {
+		return err
+	}
+	*s1 = NewSystem(s.Equations...)
+	return nil
+}
+
+// MarshalText writes the system in the same format as String, e.g. { 1x₁ + 2x₂ = 3, 4x₁ + 5x₂ = 6 }. Systems which
+// contain infinite or NaN values can't be written, because the text format can't represent them.
+func (s1 System) MarshalText() ([]byte, error) {
+	for i, e := range s1 {
+		if err := checkFiniteText(e, fmt.Sprintf("equation %d of the system", i+1)); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(s1.String()), nil
+}
+
+// UnmarshalText reads the system from the format written by String, e.g. { 1x₁ + 2x₂ = 3, 4x₁ + 5x₂ = 6 }
+func (s1 *System) UnmarshalText(text []byte) error {
+	s := string(text)
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") && strings.TrimSpace(trimmed[1:len(trimmed)-1]) == "" {
+		*s1 = System{}
+		return nil
+	}
+	system, err := DefaultVariables(defaultVariableCount(s)).ParseSystem(s)
+	if err != nil {
+		return fmt.Errorf("cannot read system: %v", err)
+	}
+	*s1 = system
+	return nil
+}
+
+// MarshalBinary writes the system using the binary format described by EncodingVersion.
+func (s1 System) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter(binarySystem)
+	w.count(len(s1))
+	for _, e := range s1 {
+		w.equation(e)
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalBinary reads the system from the binary format described by EncodingVersion.
+func (s1 *System) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data, binarySystem, "system")
+	if err != nil {
+		return err
+	}
+	// Each equation takes at least 9 bytes.
+	s := make(System, r.count(9))
+	for i := range s {
+		s[i] = r.equation()
+	}
+	if err := r.close(); err != nil {
+		return err
+	}
+	*s1 = s
+	return nil
+}
+
+type parameterizationJSON struct {
+	Version          int      `json:"version"`
+	Basepoint        Vector   `json:"basepoint"`
+	DirectionVectors []Vector `json:"directionVectors"`
+}
+
+// MarshalJSON writes the parameterization using the JSON schema described by EncodingVersion.
+func (p1 Parameterization) MarshalJSON() ([]byte, error) {
+	directionVectors := p1.DirectionVectors
+	if directionVectors == nil {
+		directionVectors = []Vector{}
+	}
+	return json.Marshal(parameterizationJSON{
+		Version:          EncodingVersion,
+		Basepoint:        p1.Basepoint,
+		DirectionVectors: directionVectors,
+	})
+}
+
+// UnmarshalJSON reads the parameterization from the JSON schema described by EncodingVersion.
+func (p1 *Parameterization) UnmarshalJSON(data []byte) error {
+	var p parameterizationJSON
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("cannot read parameterization: %v", err)
+	}
+	if err := checkVersion(p.Version, "parameterization"); err != nil {
+		return err
+	}
+	*p1 = Parameterization{Basepoint: p.Basepoint, DirectionVectors: p.DirectionVectors}
+	return nil
+}
+
+// MarshalText writes the parameterization as its basepoint followed by each direction vector multiplied by a free
+// variable, e.g. [1, 0] + t[-1, 1]
+func (p1 Parameterization) MarshalText() ([]byte, error) {
+	buf := bytes.NewBufferString(p1.Basepoint.String())
+	for i, d := range p1.DirectionVectors {
+		buf.WriteString(" + ")
+		buf.WriteString(getFreeVariableName(i))
+		buf.WriteString(d.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText reads the parameterization from the format written by MarshalText, e.g. [1, 0] + t[-1, 1]. The names
+// of the free variables are ignored.
+func (p1 *Parameterization) UnmarshalText(text []byte) error {
+	var parts []string
+	depth, start := 0, 0
+	s := string(text)
+	for i, r := range s {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '+' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+
+	basepoint, err := parseVector(parts[0])
+	if err != nil {
+		return fmt.Errorf("cannot read the basepoint of the parameterization: %v", err)
+	}
+	directionVectors := make([]Vector, len(parts)-1)
+	for i, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		bracket := strings.Index(part, "[")
+		if bracket < 0 || (bracket > 0 && !isIdentifier(part[:bracket])) {
+			return fmt.Errorf("cannot read direction vector %d of the parameterization, %q must be a free variable name followed by a vector", i+1, part)
+		}
+		if directionVectors[i], err = parseVector(part[bracket:]); err != nil {
+			return fmt.Errorf("cannot read direction vector %d of the parameterization: %v", i+1, err)
+		}
+	}
+	*p1 = Parameterization{Basepoint: basepoint, DirectionVectors: directionVectors}
+	return nil
+}
+
+// MarshalBinary writes the parameterization using the binary format described by EncodingVersion.
+func (p1 Parameterization) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter(binaryParameterization)
+	w.vector(p1.Basepoint)
+	w.count(len(p1.DirectionVectors))
+	for _, d := range p1.DirectionVectors {
+		w.vector(d)
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalBinary reads the parameterization from the binary format described by EncodingVersion.
+func (p1 *Parameterization) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader(data, binaryParameterization, "parameterization")
+	if err != nil {
+		return err
+	}
+	basepoint := r.vector()
+	// Each vector takes at least 1 byte.
+	directionVectors := make([]Vector, r.count(1))
+	for i := range directionVectors {
+		directionVectors[i] = r.vector()
+	}
+	if err := r.close(); err != nil {
+		return err
+	}
+	*p1 = Parameterization{Basepoint: basepoint, DirectionVectors: directionVectors}
+	return nil
+}
+
+// binaryWriter writes values in the binary format described by EncodingVersion.
+type binaryWriter struct {
+	bytes.Buffer
+}
+
+func newBinaryWriter(kind byte) *binaryWriter {
+	w := &binaryWriter{}
+	w.WriteByte(EncodingVersion)
+	w.WriteByte(kind)
+	return w
+}
+
+func (w *binaryWriter) count(n int) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], uint64(n))])
+}
+
+func (w *binaryWriter) float(v float64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(v))
+	w.Write(buf[:])
+}
+
+func (w *binaryWriter) vector(v Vector) {
+	w.count(len(v))
+	for _, value := range v {
+		w.float(value)
+	}
+}
+
+func (w *binaryWriter) equation(e Equation) {
+	w.vector(e.NormalVector)
+	w.float(e.ConstantTerm)
+}
+
+// binaryReader reads values in the binary format described by EncodingVersion. The first error stops reading, and is
+// returned by close.
+type binaryReader struct {
+	data []byte
+	name string
+	err  error
+}
+
+func newBinaryReader(data []byte, kind byte, name string) (*binaryReader, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("cannot read %s, the data is too short", name)
+	}
+	if err := checkVersion(int(data[0]), name); err != nil {
+		return nil, err
+	}
+	if data[1] != kind {
+		return nil, fmt.Errorf("cannot read %s, the data contains type %q rather than %q", name, data[1], kind)
+	}
+	return &binaryReader{data: data[2:], name: name}, nil
+}
+
+func (r *binaryReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+	r.data = nil
+}
+
+// count reads a count of items which each take at least minSize bytes, so that corrupt data can't cause a large
+// allocation.
+func (r *binaryReader) count(minSize int) int {
+	if r.err != nil {
+		return 0
+	}
+	n, size := binary.Uvarint(r.data)
+	if size <= 0 {
+		r.fail(fmt.Errorf("cannot read %s, the data is truncated or corrupt", r.name))
+		return 0
+	}
+	r.data = r.data[size:]
+	if n > uint64(len(r.data)/minSize) {
+		r.fail(fmt.Errorf("cannot read %s, the data is truncated", r.name))
+		return 0
+	}
+	return int(n)
+}
+
+func (r *binaryReader) float() float64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) < 8 {
+		r.fail(fmt.Errorf("cannot read %s, the data is truncated", r.name))
+		return 0
+	}
+	v := math.Float64frombits(binary.BigEndian.Uint64(r.data))
+	r.data = r.data[8:]
+	return v
+}
+
+func (r *binaryReader) vector() Vector {
+	v := make(Vector, r.count(8))
+	for i := range v {
+		v[i] = r.float()
+	}
+	return v
+}
+
+func (r *binaryReader) equation() Equation {
+	normalVector := r.vector()
+	return NewEquation(normalVector, r.float())
+}
+
+func (r *binaryReader) close() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) > 0 {
+		return errors.New("cannot read " + r.name + ", there is unexpected data at the end")
+	}
+	return nil
+}
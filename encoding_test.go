@@ -0,0 +1,291 @@
+package linear
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEncodingRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		// newValue returns a pointer to a new value of the same type as the input.
+		newValue func() interface{}
+	}{
+		{
+			name:     "vector",
+			input:    NewVector(1, -2.5, 1e-7, 0.30000000000000004, 1e21),
+			newValue: func() interface{} { return new(Vector) },
+		},
+		{
+			name:     "empty vector",
+			input:    Vector{},
+			newValue: func() interface{} { return new(Vector) },
+		},
+		{
+			name:     "radian",
+			input:    NewRadian(90),
+			newValue: func() interface{} { return new(Radian) },
+		},
+		{
+			name:     "equation",
+			input:    NewEquation(NewVector(-1.346, 0, 0.1, 5e-12), -8.15),
+			newValue: func() interface{} { return new(Equation) },
+		},
+		{
+			name: "system",
+			input: NewSystem(
+				NewEquation(NewVector(5.862, 1.178, -10.366), -8.15),
+				NewEquation(NewVector(-2.931, -0.589, 5.183), -4.075),
+			),
+			newValue: func() interface{} { return new(System) },
+		},
+		{
+			name:     "empty system",
+			input:    System{},
+			newValue: func() interface{} { return new(System) },
+		},
+		{
+			name: "parameterization",
+			input: Parameterization{
+				Basepoint:        NewVector(-10.647, 0, 0),
+				DirectionVectors: []Vector{NewVector(-1.882, 1, 0), NewVector(10.016, 0, 1e+22)},
+			},
+			newValue: func() interface{} { return new(Parameterization) },
+		},
+		{
+			name:     "point parameterization",
+			input:    Parameterization{Basepoint: NewVector(1, 2), DirectionVectors: []Vector{}},
+			newValue: func() interface{} { return new(Parameterization) },
+		},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.input)
+		if err != nil {
+			t.Errorf("%s: unexpected JSON marshal error: %v", test.name, err)
+			continue
+		}
+		fromJSON := test.newValue()
+		if err := json.Unmarshal(data, fromJSON); err != nil {
+			t.Errorf("%s: unexpected JSON unmarshal error: %v", test.name, err)
+		} else if actual := reflect.ValueOf(fromJSON).Elem().Interface(); !reflect.DeepEqual(actual, test.input) {
+			t.Errorf("%s: expected the JSON %s to round trip to %#v, but got %#v", test.name, data, test.input, actual)
+		}
+
+		text, err := test.input.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Errorf("%s: unexpected text marshal error: %v", test.name, err)
+			continue
+		}
+		fromText := test.newValue()
+		if err := fromText.(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			t.Errorf("%s: unexpected text unmarshal error: %v", test.name, err)
+		} else if actual := reflect.ValueOf(fromText).Elem().Interface(); !reflect.DeepEqual(actual, test.input) {
+			t.Errorf("%s: expected the text %s to round trip to %#v, but got %#v", test.name, text, test.input, actual)
+		}
+
+		binary, err := test.input.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: unexpected binary marshal error: %v", test.name, err)
+			continue
+		}
+		fromBinary := test.newValue()
+		if err := fromBinary.(encoding.BinaryUnmarshaler).UnmarshalBinary(binary); err != nil {
+			t.Errorf("%s: unexpected binary unmarshal error: %v", test.name, err)
+		} else if actual := reflect.ValueOf(fromBinary).Elem().Interface(); !reflect.DeepEqual(actual, test.input) {
+			t.Errorf("%s: expected the binary data to round trip to %#v, but got %#v", test.name, test.input, actual)
+		}
+	}
+}
+
+func TestEncodingBinaryKeepsSpecialValues(t *testing.T) {
+	v := NewVector(math.Inf(1), math.Inf(-1), math.Copysign(0, -1))
+	data, _ := v.MarshalBinary()
+	var actual Vector
+	if err := actual.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(actual[0], 1) || !math.IsInf(actual[1], -1) || !math.Signbit(actual[2]) {
+		t.Errorf("expected %v, but got %v", v, actual)
+	}
+}
+
+func TestEncodingTextKeepsSpecialValuesInVectors(t *testing.T) {
+	p := Parameterization{
+		Basepoint:        NewVector(math.Inf(1), 0),
+		DirectionVectors: []Vector{NewVector(math.Inf(-1), 1)},
+	}
+	text, err := p.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actual Parameterization
+	if err := actual.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error reading %s: %v", text, err)
+	}
+	if !math.IsInf(actual.Basepoint[0], 1) || !math.IsInf(actual.DirectionVectors[0][0], -1) {
+		t.Errorf("expected %v, but got %v", p, actual)
+	}
+}
+
+func TestEncodingJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "vector",
+			input:    NewVector(1, 2.5),
+			expected: `[1,2.5]`,
+		},
+		{
+			name:     "radian",
+			input:    Radian(1.5),
+			expected: `1.5`,
+		},
+		{
+			name:     "equation",
+			input:    NewEquation(NewVector(1, 2), 3),
+			expected: `{"version":1,"normalVector":[1,2],"constantTerm":3}`,
+		},
+		{
+			name:     "system",
+			input:    NewSystem(NewEquation(NewVector(1), 2)),
+			expected: `{"version":1,"equations":[{"version":1,"normalVector":[1],"constantTerm":2}]}`,
+		},
+		{
+			name:     "parameterization",
+			input:    Parameterization{Basepoint: NewVector(1, 0), DirectionVectors: []Vector{NewVector(-1, 1)}},
+			expected: `{"version":1,"basepoint":[1,0],"directionVectors":[[-1,1]]}`,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := json.Marshal(test.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(actual) != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestEncodingErrors(t *testing.T) {
+	vectorData, _ := NewVector(1, 2).MarshalBinary()
+	tests := []struct {
+		name                 string
+		f                    func() error
+		expectedErrorMessage string
+	}{
+		{
+			name: "unsupported JSON version",
+			f: func() error {
+				var e Equation
+				return json.Unmarshal([]byte(`{"version":2,"normalVector":[1],"constantTerm":1}`), &e)
+			},
+			expectedErrorMessage: "cannot read equation with schema version 2, the supported versions are 1 to 1",
+		},
+		{
+			name: "missing JSON version",
+			f: func() error {
+				var s System
+				return json.Unmarshal([]byte(`{"equations":[]}`), &s)
+			},
+			expectedErrorMessage: "cannot read system with schema version 0, the supported versions are 1 to 1",
+		},
+		{
+			name: "invalid text vector",
+			f: func() error {
+				var v Vector
+				return v.UnmarshalText([]byte("[1, a]"))
+			},
+			expectedErrorMessage: "cannot read value 2 of vector \"1, a\": strconv.ParseFloat: parsing \"a\": invalid syntax",
+		},
+		{
+			name: "text vector without brackets",
+			f: func() error {
+				var v Vector
+				return v.UnmarshalText([]byte("1, 2"))
+			},
+			expectedErrorMessage: "cannot read vector \"1, 2\", it must be surrounded by square brackets",
+		},
+		{
+			name: "text equation with an unknown variable",
+			f: func() error {
+				var e Equation
+				return e.UnmarshalText([]byte("1x₁ + 2y = 3"))
+			},
+			expectedErrorMessage: "cannot read equation: unknown variable \"y\" at position 8",
+		},
+		{
+			name: "text parameterization without a vector",
+			f: func() error {
+				var p Parameterization
+				return p.UnmarshalText([]byte("[1, 2] + t"))
+			},
+			expectedErrorMessage: "cannot read direction vector 1 of the parameterization, \"t\" must be a free variable name followed by a vector",
+		},
+		{
+			name: "text equation with an infinite coefficient",
+			f: func() error {
+				_, err := NewEquation(NewVector(1, 0, math.Inf(1)), 1).MarshalText()
+				return err
+			},
+			expectedErrorMessage: "cannot write equation as text, coefficient 3 is +Inf",
+		},
+		{
+			name: "text system with a NaN constant term",
+			f: func() error {
+				_, err := NewSystem(NewEquation(NewVector(1), 1), NewEquation(NewVector(1), math.NaN())).MarshalText()
+				return err
+			},
+			expectedErrorMessage: "cannot write equation 2 of the system as text, the constant term is NaN",
+		},
+		{
+			name: "binary data for a different type",
+			f: func() error {
+				var e Equation
+				return e.UnmarshalBinary(vectorData)
+			},
+			expectedErrorMessage: "cannot read equation, the data contains type 'V' rather than 'E'",
+		},
+		{
+			name: "truncated binary data",
+			f: func() error {
+				var v Vector
+				return v.UnmarshalBinary(vectorData[:len(vectorData)-1])
+			},
+			expectedErrorMessage: "cannot read vector, the data is truncated",
+		},
+		{
+			name: "binary data with extra bytes",
+			f: func() error {
+				var v Vector
+				return v.UnmarshalBinary(append(append([]byte{}, vectorData...), 0))
+			},
+			expectedErrorMessage: "cannot read vector, there is unexpected data at the end",
+		},
+		{
+			name: "unsupported binary version",
+			f: func() error {
+				var v Vector
+				return v.UnmarshalBinary(append([]byte{2}, vectorData[1:]...))
+			},
+			expectedErrorMessage: "cannot read vector with schema version 2, the supported versions are 1 to 1",
+		},
+	}
+
+	for _, test := range tests {
+		err := test.f()
+		if err == nil || err.Error() != test.expectedErrorMessage {
+			t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+		}
+	}
+}
@@ -43,6 +43,73 @@ func NewEquation(normalVector Vector, constantTerm float64) Equation {
 	}
 }
 
+// NewEquationFromPointAndNormal creates the hyperplane which passes through the point and is perpendicular to the
+// normal vector.
+func NewEquationFromPointAndNormal(point Vector, normal Vector) (Equation, error) {
+	if normal.IsZeroVector() {
+		return Equation{}, errors.New("cannot create an equation from a zero normal vector")
+	}
+	c, err := normal.DotProduct(point)
+	if err != nil {
+		return Equation{}, fmt.Errorf("cannot create an equation because the point has %d dimensions and the normal vector has %d dimensions", len(point), len(normal))
+	}
+	return NewEquation(normal, c), nil
+}
+
+// NewLineThroughPoints creates the 2D line which passes through the points a and b.
+func NewLineThroughPoints(a, b Vector) (Equation, error) {
+	if len(a) != 2 || len(b) != 2 {
+		return Equation{}, fmt.Errorf("a line requires 2D points, but the points have %d and %d dimensions", len(a), len(b))
+	}
+	direction, _ := b.Sub(a)
+	if direction.IsZeroVector() {
+		return Equation{}, errors.New("the points are the same, so they do not define a line")
+	}
+	// Rotate the direction by 90 degrees to get the normal vector.
+	return NewEquationFromPointAndNormal(a, NewVector(-direction[1], direction[0]))
+}
+
+// NewPlaneThroughPoints creates the 3D plane which passes through the points a, b and c. The normal vector is the
+// cross product of the vectors from a to b and from a to c.
+func NewPlaneThroughPoints(a, b, c Vector) (Equation, error) {
+	if len(a) != 3 || len(b) != 3 || len(c) != 3 {
+		return Equation{}, fmt.Errorf("a plane requires 3D points, but the points have %d, %d and %d dimensions", len(a), len(b), len(c))
+	}
+	ab, _ := b.Sub(a)
+	ac, _ := c.Sub(a)
+	normal, _ := ab.CrossProduct(ac)
+	if normal.IsZeroVector() {
+		return Equation{}, errors.New("the points are collinear, so they do not define a plane")
+	}
+	return NewEquationFromPointAndNormal(a, normal)
+}
+
+// NewHyperplaneThroughPoints creates the hyperplane which passes through n points in n dimensions, e.g. 2 points in
+// 2D or 3 points in 3D. The normal vector is the generalized cross product of the vectors from the first point to
+// each of the others.
+func NewHyperplaneThroughPoints(points ...Vector) (Equation, error) {
+	if len(points) < 2 {
+		return Equation{}, fmt.Errorf("a hyperplane requires at least 2 points, but %d were provided", len(points))
+	}
+	directions := make([]Vector, len(points)-1)
+	for i, p := range points {
+		if len(p) != len(points) {
+			return Equation{}, fmt.Errorf("a hyperplane through %d points requires points with %d dimensions, but the point at index %d has %d dimensions", len(points), len(points), i, len(p))
+		}
+		if i > 0 {
+			directions[i-1], _ = p.Sub(points[0])
+		}
+	}
+	normal, err := GeneralizedCrossProduct(directions...)
+	if err != nil {
+		return Equation{}, err
+	}
+	if normal.IsZeroVector() {
+		return Equation{}, errors.New("the points are not affinely independent, so they do not define a hyperplane")
+	}
+	return NewEquationFromPointAndNormal(points[0], normal)
+}
+
 // NonZeroValuePoint finds a point on the Line where one of the dimension values is not zero.
 // If a non-zero coefficient is not found, ok is set to false.
 func (l1 Equation) NonZeroValuePoint() (nonzero Vector, ok bool) {
@@ -72,27 +139,42 @@ func firstNonZeroElement(v Vector) (index int, value float64, ok bool) {
 }
 
 func (l1 Equation) String() string {
+	return Formatter{}.Equation(l1)
+}
+
+// format writes out the equation using the formatter, and the name function to get the name of the variable at each
+// index.
+func (l1 Equation) format(f Formatter, name func(index int) string) string {
 	buf := bytes.Buffer{}
 	for i, p := range l1.NormalVector {
-		if i == 0 {
-			// The first element should have an integrated +/- sign.
-			buf.WriteString(fmt.Sprintf("%v", p))
-			// Write out the x_1 specifier.
-			buf.WriteString(fmt.Sprintf("x%s", getSubscript(i+1)))
+		if f.SuppressZeroTerms && tolerance.IsWithin(p, 0, DefaultTolerance) {
 			continue
 		}
-
-		// For anything after index zero, the sign becomes the operator.
-		buf.WriteString(operator(p))
-		buf.WriteString(fmt.Sprintf("%v", math.Abs(p)))
-		// Write out the x_1 specifier.
-		buf.WriteString(fmt.Sprintf("x%s", getSubscript(i+1)))
+		if buf.Len() == 0 {
+			// The first element should have an integrated +/- sign.
+			buf.WriteString(f.coefficient(p, true))
+		} else {
+			// For anything after the first element, the sign becomes the operator.
+			buf.WriteString(operator(p))
+			buf.WriteString(f.coefficient(math.Abs(p), false))
+		}
+		// Write out the variable name.
+		buf.WriteString(name(i))
+	}
+	if buf.Len() == 0 {
+		buf.WriteString(f.Number(0))
 	}
 	// Write out the constant term.
-	buf.WriteString(fmt.Sprintf(" = %v", l1.ConstantTerm))
+	buf.WriteString(" = ")
+	buf.WriteString(f.Number(l1.ConstantTerm))
 	return buf.String()
 }
 
+// defaultVariableName returns the name of the variable at the index, e.g. x₁ for index 0.
+func defaultVariableName(index int) string {
+	return "x" + getSubscript(index+1)
+}
+
 func operator(v float64) string {
 	if v < 0 {
 		return " - "
@@ -282,3 +364,65 @@ func (l1 Equation) CancelTerm(target Equation, termIndex int) (Equation, error)
 func (l1 Equation) Scale(scalar float64) Equation {
 	return NewEquation(l1.NormalVector.Scale(scalar), l1.ConstantTerm*scalar)
 }
+
+// SignedDistanceTo calculates the distance from the hyperplane to the point v. The distance is positive when the point
+// is on the side of the hyperplane that the normal vector points towards, and negative on the other side.
+func (l1 Equation) SignedDistanceTo(v Vector) (float64, error) {
+	offset, err := l1.offset(v)
+	if err != nil {
+		return 0, err
+	}
+	return offset / l1.NormalVector.Magnitude(), nil
+}
+
+// ClosestPointTo calculates the point on the hyperplane which is closest to the point v, i.e. the projection of v
+// onto the hyperplane.
+func (l1 Equation) ClosestPointTo(v Vector) (Vector, error) {
+	return l1.movePointAlongNormal(v, 1)
+}
+
+// Reflect calculates the reflection of the point v across the hyperplane.
+func (l1 Equation) Reflect(v Vector) (Vector, error) {
+	return l1.movePointAlongNormal(v, 2)
+}
+
+// movePointAlongNormal moves the point v towards the hyperplane along the normal vector. A multiple of 1 moves it onto
+// the hyperplane, and a multiple of 2 moves it the same distance again, to the other side.
+func (l1 Equation) movePointAlongNormal(v Vector, multiple float64) (Vector, error) {
+	offset, err := l1.offset(v)
+	if err != nil {
+		return Vector{}, err
+	}
+	normalSquared, _ := l1.NormalVector.DotProduct(l1.NormalVector)
+	// No need to check the error, the dimensions were checked when calculating the offset.
+	return v.Sub(l1.NormalVector.Scale(multiple * offset / normalSquared))
+}
+
+// offset calculates n·v - c, which is zero for points on the hyperplane.
+func (l1 Equation) offset(v Vector) (float64, error) {
+	if len(l1.NormalVector) != len(v) {
+		return 0, fmt.Errorf("the equation has %d dimensions, but the point has %d dimensions", len(l1.NormalVector), len(v))
+	}
+	if l1.NormalVector.IsZeroVector() {
+		return 0, errors.New("the equation has a zero normal vector, so it does not describe a hyperplane")
+	}
+	dp, _ := l1.NormalVector.DotProduct(v)
+	return dp - l1.ConstantTerm, nil
+}
+
+// AngleBetween calculates the angle between two hyperplanes, which is the angle between their normal vectors. Since a
+// hyperplane has no direction, the result is always between 0 and π/2 radians.
+func (l1 Equation) AngleBetween(l2 Equation) (Radian, error) {
+	if l1.NormalVector.IsZeroVector() || l2.NormalVector.IsZeroVector() {
+		return 0, errors.New("the equation has a zero normal vector, so it does not describe a hyperplane")
+	}
+	u1, u2 := l1.NormalVector.Normalize(), l2.NormalVector.Normalize()
+	cosine, err := u1.DotProduct(u2)
+	if err != nil {
+		return 0, err
+	}
+	// acos loses precision for small angles, so use the length of the component of u2 which is orthogonal to u1
+	// as the sine instead.
+	rejection, _ := u2.Sub(u1.Scale(cosine))
+	return Radian(math.Atan2(rejection.Magnitude(), math.Abs(cosine))), nil
+}
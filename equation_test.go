@@ -1,6 +1,7 @@
 package linear
 
 import (
+	"math"
 	"strings"
 	"testing"
 
@@ -555,3 +556,304 @@ func TestEquationScaleFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestEquationPointFunctions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		e                    Equation
+		v                    Vector
+		distance             float64
+		closest              Vector
+		reflection           Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:       "point above the line y = 1",
+			e:          NewEquation(NewVector(0, 1), 1),
+			v:          NewVector(3, 4),
+			distance:   3,
+			closest:    NewVector(3, 1),
+			reflection: NewVector(3, -2),
+		},
+		{
+			name:       "point below the line y = 1 with a scaled normal vector",
+			e:          NewEquation(NewVector(0, -2), -2),
+			v:          NewVector(3, 4),
+			distance:   -3,
+			closest:    NewVector(3, 1),
+			reflection: NewVector(3, -2),
+		},
+		{
+			name:       "point on the line",
+			e:          NewEquation(NewVector(1, 1), 2),
+			v:          NewVector(1, 1),
+			distance:   0,
+			closest:    NewVector(1, 1),
+			reflection: NewVector(1, 1),
+		},
+		{
+			name:       "origin and the plane x + y + z = 3",
+			e:          NewEquation(NewVector(1, 1, 1), 3),
+			v:          NewVector(0, 0, 0),
+			distance:   -math.Sqrt(3),
+			closest:    NewVector(1, 1, 1),
+			reflection: NewVector(2, 2, 2),
+		},
+		{
+			name:       "4D hyperplane",
+			e:          NewEquation(NewVector(0, 0, 0, 2), 4),
+			v:          NewVector(1, 2, 3, 4),
+			distance:   2,
+			closest:    NewVector(1, 2, 3, 2),
+			reflection: NewVector(1, 2, 3, 0),
+		},
+		{
+			name:                 "mismatched dimensions",
+			e:                    NewEquation(NewVector(1, 1), 2),
+			v:                    NewVector(1, 1, 1),
+			expectedErrorMessage: "the equation has 2 dimensions, but the point has 3 dimensions",
+		},
+		{
+			name:                 "zero normal vector",
+			e:                    NewEquation(NewVector(0, 0), 2),
+			v:                    NewVector(1, 1),
+			expectedErrorMessage: "the equation has a zero normal vector, so it does not describe a hyperplane",
+		},
+	}
+
+	for _, test := range tests {
+		distance, err := test.e.SignedDistanceTo(test.v)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			if _, err := test.e.ClosestPointTo(test.v); err == nil {
+				t.Errorf("%s: expected an error from ClosestPointTo", test.name)
+			}
+			if _, err := test.e.Reflect(test.v); err == nil {
+				t.Errorf("%s: expected an error from Reflect", test.name)
+			}
+			continue
+		}
+		if !tolerance.IsWithin(distance, test.distance, DefaultTolerance) {
+			t.Errorf("%s: expected distance %v, but got %v", test.name, test.distance, distance)
+		}
+		closest, err := test.e.ClosestPointTo(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !closest.Eq(test.closest) {
+			t.Errorf("%s: expected closest point %v, but got %v", test.name, test.closest, closest)
+		}
+		reflection, err := test.e.Reflect(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !reflection.Eq(test.reflection) {
+			t.Errorf("%s: expected reflection %v, but got %v", test.name, test.reflection, reflection)
+		}
+	}
+}
+
+func TestEquationAngleBetweenFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		a                    Equation
+		b                    Equation
+		expected             Radian
+		expectedErrorMessage string
+	}{
+		{
+			name:     "parallel lines",
+			a:        NewEquation(NewVector(1, 1), 1),
+			b:        NewEquation(NewVector(2, 2), 5),
+			expected: 0,
+		},
+		{
+			name:     "normal vectors in opposite directions",
+			a:        NewEquation(NewVector(1, 1), 1),
+			b:        NewEquation(NewVector(-1, -1), 5),
+			expected: 0,
+		},
+		{
+			name:     "perpendicular planes",
+			a:        NewEquation(NewVector(1, 0, 0), 1),
+			b:        NewEquation(NewVector(0, 0, 3), 1),
+			expected: NewRadian(90),
+		},
+		{
+			name:     "45 degrees",
+			a:        NewEquation(NewVector(1, 0), 1),
+			b:        NewEquation(NewVector(-1, 1), 1),
+			expected: NewRadian(45),
+		},
+		{
+			name:                 "mismatched dimensions",
+			a:                    NewEquation(NewVector(1, 0), 1),
+			b:                    NewEquation(NewVector(1, 1, 1), 1),
+			expectedErrorMessage: "cannot calculate the dot product of the vectors because they have different dimensions (2 and 3)",
+		},
+		{
+			name:                 "zero normal vector",
+			a:                    NewEquation(NewVector(1, 0), 1),
+			b:                    NewEquation(NewVector(0, 0), 1),
+			expectedErrorMessage: "the equation has a zero normal vector, so it does not describe a hyperplane",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.a.AngleBetween(test.b)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if !tolerance.IsWithin(float64(actual), float64(test.expected), DefaultTolerance) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestEquationConstructors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		f                    func() (Equation, error)
+		points               []Vector
+		expected             Equation
+		expectedErrorMessage string
+	}{
+		{
+			name: "point and normal",
+			f: func() (Equation, error) {
+				return NewEquationFromPointAndNormal(NewVector(1, 2, 3), NewVector(0, 0, 2))
+			},
+			points:   []Vector{NewVector(1, 2, 3)},
+			expected: NewEquation(NewVector(0, 0, 1), 3),
+		},
+		{
+			name: "point and zero normal",
+			f: func() (Equation, error) {
+				return NewEquationFromPointAndNormal(NewVector(1, 2, 3), NewVector(0, 0, 0))
+			},
+			expectedErrorMessage: "cannot create an equation from a zero normal vector",
+		},
+		{
+			name: "point and normal with mismatched dimensions",
+			f: func() (Equation, error) {
+				return NewEquationFromPointAndNormal(NewVector(1, 2), NewVector(0, 0, 1))
+			},
+			expectedErrorMessage: "cannot create an equation because the point has 2 dimensions and the normal vector has 3 dimensions",
+		},
+		{
+			name: "line through two points",
+			f: func() (Equation, error) {
+				return NewLineThroughPoints(NewVector(0, 1), NewVector(2, 5))
+			},
+			points:   []Vector{NewVector(0, 1), NewVector(2, 5), NewVector(1, 3)},
+			expected: NewEquation(NewVector(-2, 1), 1),
+		},
+		{
+			name: "vertical line through two points",
+			f: func() (Equation, error) {
+				return NewLineThroughPoints(NewVector(3, 1), NewVector(3, -1))
+			},
+			points:   []Vector{NewVector(3, 1), NewVector(3, -1)},
+			expected: NewEquation(NewVector(1, 0), 3),
+		},
+		{
+			name: "line through the same point twice",
+			f: func() (Equation, error) {
+				return NewLineThroughPoints(NewVector(3, 1), NewVector(3, 1))
+			},
+			expectedErrorMessage: "the points are the same, so they do not define a line",
+		},
+		{
+			name: "line through 3D points",
+			f: func() (Equation, error) {
+				return NewLineThroughPoints(NewVector(3, 1, 1), NewVector(3, 1))
+			},
+			expectedErrorMessage: "a line requires 2D points, but the points have 3 and 2 dimensions",
+		},
+		{
+			name: "plane through three points",
+			f: func() (Equation, error) {
+				return NewPlaneThroughPoints(NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1))
+			},
+			points:   []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1)},
+			expected: NewEquation(NewVector(1, 1, 1), 1),
+		},
+		{
+			name: "plane through collinear points",
+			f: func() (Equation, error) {
+				return NewPlaneThroughPoints(NewVector(1, 1, 1), NewVector(2, 2, 2), NewVector(3, 3, 3))
+			},
+			expectedErrorMessage: "the points are collinear, so they do not define a plane",
+		},
+		{
+			name: "plane through 2D points",
+			f: func() (Equation, error) {
+				return NewPlaneThroughPoints(NewVector(1, 1), NewVector(2, 2, 2), NewVector(3, 3, 3))
+			},
+			expectedErrorMessage: "a plane requires 3D points, but the points have 2, 3 and 3 dimensions",
+		},
+		{
+			name: "hyperplane through four points",
+			f: func() (Equation, error) {
+				return NewHyperplaneThroughPoints(NewVector(2, 0, 0, 0), NewVector(0, 2, 0, 0), NewVector(0, 0, 2, 0), NewVector(0, 0, 0, 2))
+			},
+			points:   []Vector{NewVector(2, 0, 0, 0), NewVector(0, 2, 0, 0), NewVector(0, 0, 2, 0), NewVector(0, 0, 0, 2), NewVector(0.5, 0.5, 0.5, 0.5)},
+			expected: NewEquation(NewVector(1, 1, 1, 1), 2),
+		},
+		{
+			name: "hyperplane through three 3D points is a plane",
+			f: func() (Equation, error) {
+				return NewHyperplaneThroughPoints(NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1))
+			},
+			points:   []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1)},
+			expected: NewEquation(NewVector(1, 1, 1), 1),
+		},
+		{
+			name: "hyperplane through degenerate points",
+			f: func() (Equation, error) {
+				return NewHyperplaneThroughPoints(NewVector(1, 0, 0, 0), NewVector(2, 0, 0, 0), NewVector(3, 0, 0, 0), NewVector(0, 0, 0, 1))
+			},
+			expectedErrorMessage: "the points are not affinely independent, so they do not define a hyperplane",
+		},
+		{
+			name: "hyperplane through too few points",
+			f: func() (Equation, error) {
+				return NewHyperplaneThroughPoints(NewVector(1, 0, 0), NewVector(2, 0, 0))
+			},
+			expectedErrorMessage: "a hyperplane through 2 points requires points with 2 dimensions, but the point at index 0 has 3 dimensions",
+		},
+		{
+			name: "hyperplane through a single point",
+			f: func() (Equation, error) {
+				return NewHyperplaneThroughPoints(NewVector(1))
+			},
+			expectedErrorMessage: "a hyperplane requires at least 2 points, but 1 were provided",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.f()
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+		}
+		if eq, _ := actual.Eq(test.expected); !eq {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+		for _, p := range test.points {
+			if d, _ := actual.SignedDistanceTo(p); !tolerance.IsWithin(d, 0, DefaultTolerance) {
+				t.Errorf("%s: expected %v to be on %v, but it was %v away", test.name, p, actual, d)
+			}
+		}
+	}
+}
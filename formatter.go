@@ -0,0 +1,289 @@
+package linear
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// SignStyle determines how the sign of a number is written.
+type SignStyle int
+
+const (
+	// SignNegativeOnly writes a minus sign in front of negative numbers, and nothing in front of positive numbers. Negative
+	// zero is written as 0.
+	SignNegativeOnly SignStyle = iota
+	// SignAlways writes a plus sign in front of positive numbers and zero, as well as a minus sign in front of negative
+	// numbers.
+	SignAlways
+)
+
+// defaultMaxDenominator is the largest denominator used to write fractions if Formatter.MaxDenominator is not set.
+const defaultMaxDenominator = 100
+
+// Formatter controls how numbers and variable names are written by the String functions of Vector, Equation, System,
+// Parameterization and Quaternion, and by LaTeXRenderer. The zero value writes numbers in the same way as %v, e.g.
+// { 1x₁ + 2x₂ = 3 }.
+//
+// The same options are available through fmt verbs, e.g. fmt.Sprintf("%.2f", e) writes each number with two digits
+// after the decimal point, and the + flag suppresses unit coefficients and zero terms, e.g. fmt.Sprintf("%+v", e).
+type Formatter struct {
+	// Verb is the strconv.FormatFloat format used to write numbers, i.e. 'e', 'f' or 'g'. If it is zero, numbers are
+	// written as %v would write them.
+	Verb byte
+	// Precision is the precision passed to strconv.FormatFloat when Verb is set. -1 uses the smallest number of digits
+	// required to represent the value exactly.
+	Precision int
+	// Fractions writes numbers which are close to a fraction with a small denominator as a fraction, e.g. 1/3.
+	Fractions bool
+	// MaxDenominator is the largest denominator used to write fractions. If it is zero, 100 is used.
+	MaxDenominator int
+	// SuppressUnitCoefficients writes coefficients of 1 and -1 as just the variable name, e.g. x₁ - x₂ rather than
+	// 1x₁ - 1x₂.
+	SuppressUnitCoefficients bool
+	// SuppressZeroTerms leaves out terms which have a coefficient of zero.
+	SuppressZeroTerms bool
+	// Sign determines how the sign of a number is written.
+	Sign SignStyle
+	// ASCIISubscripts writes subscripts using an underscore, e.g. x_1 rather than x₁.
+	ASCIISubscripts bool
+}
+
+// Number writes out a single number.
+func (f Formatter) Number(v float64) string {
+	return f.number(v, f.Sign == SignAlways)
+}
+
+// number writes out a single number, with a plus sign in front of positive numbers if plus is true.
+func (f Formatter) number(v float64, plus bool) string {
+	var s string
+	if f.Fractions {
+		s = f.fraction(v)
+	}
+	if s == "" {
+		if f.Verb == 0 {
+			s = fmt.Sprintf("%v", v)
+		} else {
+			s = strconv.FormatFloat(v, f.Verb, f.Precision, 64)
+		}
+	}
+	// Avoid writing -0, including values which round to zero.
+	if strings.HasPrefix(s, "-") && strings.Trim(s, "-0.") == "" {
+		s = s[1:]
+	}
+	if plus && !strings.HasPrefix(s, "-") && !math.IsNaN(v) {
+		s = "+" + s
+	}
+	return s
+}
+
+// fraction writes v as a fraction, e.g. -2/3, if it is within tolerance of a fraction whose denominator is no larger
+// than the maximum. Whole numbers are written without a denominator. If there is no such fraction, it returns an empty
+// string.
+func (f Formatter) fraction(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) || math.Abs(v) > 1<<53 {
+		return ""
+	}
+	maxDenominator := f.MaxDenominator
+	if maxDenominator <= 0 {
+		maxDenominator = defaultMaxDenominator
+	}
+	// Find the best rational approximation using continued fractions.
+	x := math.Abs(v)
+	h, previousH := math.Floor(x), 1.0
+	k, previousK := 1.0, 0.0
+	remainder := x - math.Floor(x)
+	for !tolerance.IsWithin(h/k, x, DefaultTolerance*math.Max(1, x)) {
+		if remainder < DefaultTolerance {
+			return ""
+		}
+		a := math.Floor(1 / remainder)
+		remainder = 1/remainder - a
+		h, previousH = a*h+previousH, h
+		k, previousK = a*k+previousK, k
+		if k > float64(maxDenominator) {
+			return ""
+		}
+	}
+	sign := ""
+	if v < 0 && h != 0 {
+		sign = "-"
+	}
+	if k == 1 {
+		return fmt.Sprintf("%s%.0f", sign, h)
+	}
+	return fmt.Sprintf("%s%.0f/%.0f", sign, h, k)
+}
+
+// coefficient writes out the coefficient of a variable. If unit coefficients are suppressed, a coefficient of 1 is
+// written as nothing, and if leading is true, a coefficient of -1 is written as just the minus sign.
+func (f Formatter) coefficient(v float64, leading bool) string {
+	if f.SuppressUnitCoefficients && tolerance.IsWithin(math.Abs(v), 1, DefaultTolerance) {
+		switch {
+		case v < 0:
+			return "-"
+		case leading && f.Sign == SignAlways:
+			return "+"
+		}
+		return ""
+	}
+	return f.number(v, leading && f.Sign == SignAlways)
+}
+
+func (f Formatter) subscript(i int) string {
+	if f.ASCIISubscripts {
+		return "_" + strconv.Itoa(i)
+	}
+	return getSubscript(i)
+}
+
+func (f Formatter) variableName(index int) string {
+	return "x" + f.subscript(index+1)
+}
+
+func (f Formatter) freeVariableName(index int) string {
+	return freeVariableName(index, f.subscript)
+}
+
+// Vector writes out the vector, e.g. [1, 2, 3]
+func (f Formatter) Vector(v Vector) string {
+	return v.format(f)
+}
+
+// Equation writes out the equation, e.g. 1x₁ + 2x₂ = 3
+func (f Formatter) Equation(e Equation) string {
+	return e.format(f, f.variableName)
+}
+
+// System writes out each equation in the system, e.g. { 1x₁ + 2x₂ = 3, 4x₁ + 5x₂ = 6 }
+func (f Formatter) System(s System) string {
+	return s.format(f, f.variableName)
+}
+
+// Parameterization writes out the value of each variable, e.g. { x₁ = 1 - t, x₂ = t }
+func (f Formatter) Parameterization(p Parameterization) string {
+	return p.format(f, f.variableName, f.freeVariableName)
+}
+
+// Quaternion writes out the quaternion, e.g. 1 - 2i + 3.5j + 0k
+func (f Formatter) Quaternion(q Quaternion) string {
+	buf := bytes.NewBufferString(f.Number(q.W))
+	for i, v := range []float64{q.X, q.Y, q.Z} {
+		buf.WriteString(operator(v))
+		buf.WriteString(f.number(math.Abs(v), false))
+		buf.WriteByte("ijk"[i])
+	}
+	return buf.String()
+}
+
+// newFormatterFromState creates a Formatter from a fmt verb and its flags. ok is false if the verb is not supported.
+func newFormatterFromState(s fmt.State, verb rune) (f Formatter, ok bool) {
+	switch verb {
+	case 'v', 's':
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		f.Verb = byte(verb)
+		if verb == 'F' {
+			f.Verb = 'f'
+		}
+		f.Precision = -1
+		if precision, ok := s.Precision(); ok {
+			f.Precision = precision
+		}
+	default:
+		return f, false
+	}
+	if s.Flag('+') {
+		f.SuppressUnitCoefficients = true
+		f.SuppressZeroTerms = true
+	}
+	return f, true
+}
+
+// writeFormatted implements fmt.Formatter for a value, using format to write it out with the Formatter described by
+// the verb, and goString to write it out for %#v.
+func writeFormatted(s fmt.State, verb rune, value interface{}, format func(f Formatter) string, goString func() string) {
+	if verb == 'v' && s.Flag('#') {
+		fmt.Fprint(s, goString())
+		return
+	}
+	f, ok := newFormatterFromState(s, verb)
+	if !ok {
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, value, format(Formatter{}))
+		return
+	}
+	output := format(f)
+	if width, ok := s.Width(); ok {
+		if padding := width - len([]rune(output)); padding > 0 {
+			if s.Flag('-') {
+				output += strings.Repeat(" ", padding)
+			} else {
+				output = strings.Repeat(" ", padding) + output
+			}
+		}
+	}
+	fmt.Fprint(s, output)
+}
+
+// Format implements fmt.Formatter. %v and %s write the vector as String does, %e, %f and %g write each value using the
+// verb and precision, e.g. %.2f, and %#v writes Go syntax.
+func (v1 Vector) Format(s fmt.State, verb rune) {
+	writeFormatted(s, verb, v1, v1.format, v1.GoString)
+}
+
+// GoString writes out the vector using Go syntax, e.g. linear.Vector{1, 2, 3}
+func (v1 Vector) GoString() string {
+	buf := bytes.NewBufferString("linear.Vector{")
+	for i, p := range v1 {
+		buf.WriteString(fmt.Sprintf("%#v", p))
+		if i < len(v1)-1 {
+			buf.WriteString(", ")
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// Format implements fmt.Formatter. %v and %s write the equation as String does, %e, %f and %g write each number using
+// the verb and precision, e.g. %.2f, the + flag suppresses unit coefficients and zero terms, and %#v writes Go syntax.
+func (l1 Equation) Format(s fmt.State, verb rune) {
+	writeFormatted(s, verb, l1, func(f Formatter) string { return f.Equation(l1) }, l1.GoString)
+}
+
+// GoString writes out the equation using Go syntax.
+func (l1 Equation) GoString() string {
+	return fmt.Sprintf("linear.Equation{NormalVector:%s, ConstantTerm:%#v}", l1.NormalVector.GoString(), l1.ConstantTerm)
+}
+
+// Format implements fmt.Formatter. %v and %s write the system as String does, %e, %f and %g write each number using
+// the verb and precision, e.g. %.2f, the + flag suppresses unit coefficients and zero terms, and %#v writes Go syntax.
+func (s1 System) Format(s fmt.State, verb rune) {
+	writeFormatted(s, verb, s1, func(f Formatter) string { return f.System(s1) }, s1.GoString)
+}
+
+// GoString writes out the system using Go syntax.
+func (s1 System) GoString() string {
+	equations := make([]string, len(s1))
+	for i, e := range s1 {
+		equations[i] = e.GoString()
+	}
+	return "linear.System{" + strings.Join(equations, ", ") + "}"
+}
+
+// Format implements fmt.Formatter. %v and %s write the parameterization as String does, %e, %f and %g write each
+// number using the verb and precision, e.g. %.2f, and %#v writes Go syntax.
+func (p1 Parameterization) Format(s fmt.State, verb rune) {
+	writeFormatted(s, verb, p1, func(f Formatter) string { return f.Parameterization(p1) }, p1.GoString)
+}
+
+// GoString writes out the parameterization using Go syntax.
+func (p1 Parameterization) GoString() string {
+	directions := make([]string, len(p1.DirectionVectors))
+	for i, d := range p1.DirectionVectors {
+		directions[i] = d.GoString()
+	}
+	return fmt.Sprintf("linear.Parameterization{Basepoint:%s, DirectionVectors:[]linear.Vector{%s}}", p1.Basepoint.GoString(), strings.Join(directions, ", "))
+}
@@ -0,0 +1,178 @@
+package linear
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestFormatterNumberFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        Formatter
+		input    float64
+		expected string
+	}{
+		{name: "default", input: 0.30000000000000004, expected: "0.30000000000000004"},
+		{name: "default negative zero", input: math.Copysign(0, -1), expected: "0"},
+		{name: "precision", f: Formatter{Verb: 'f', Precision: 2}, input: 0.30000000000000004, expected: "0.30"},
+		{name: "rounds to zero", f: Formatter{Verb: 'f', Precision: 2}, input: -0.001, expected: "0.00"},
+		{name: "exponent", f: Formatter{Verb: 'e', Precision: 1}, input: 1234, expected: "1.2e+03"},
+		{name: "plus sign", f: Formatter{Sign: SignAlways}, input: 2, expected: "+2"},
+		{name: "plus sign on a negative number", f: Formatter{Sign: SignAlways}, input: -2, expected: "-2"},
+		{name: "fraction", f: Formatter{Fractions: true}, input: 1.0 / 3, expected: "1/3"},
+		{name: "negative fraction", f: Formatter{Fractions: true}, input: -2.5, expected: "-5/2"},
+		{name: "whole number fraction", f: Formatter{Fractions: true}, input: 4, expected: "4"},
+		{name: "zero fraction", f: Formatter{Fractions: true}, input: 0, expected: "0"},
+		{name: "no fraction with a small denominator", f: Formatter{Fractions: true}, input: math.Pi, expected: "3.141592653589793"},
+		{name: "larger maximum denominator", f: Formatter{Fractions: true, MaxDenominator: 1000}, input: 355.0 / 113, expected: "355/113"},
+	}
+
+	for _, test := range tests {
+		if actual := test.f.Number(test.input); actual != test.expected {
+			t.Errorf("%s: expected '%v', but got '%v'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestFormatterEquationFunction(t *testing.T) {
+	e := NewEquation(NewVector(1, 0, -1, 0.30000000000000004), math.Copysign(0, -1))
+
+	tests := []struct {
+		name     string
+		f        Formatter
+		input    Equation
+		expected string
+	}{
+		{
+			name:     "default",
+			input:    e,
+			expected: "1x₁ + 0x₂ - 1x₃ + 0.30000000000000004x₄ = 0",
+		},
+		{
+			name:     "suppress unit coefficients and zero terms",
+			f:        Formatter{SuppressUnitCoefficients: true, SuppressZeroTerms: true, Verb: 'g', Precision: 3},
+			input:    e,
+			expected: "x₁ - x₃ + 0.3x₄ = 0",
+		},
+		{
+			name:     "leading negative unit coefficient",
+			f:        Formatter{SuppressUnitCoefficients: true, SuppressZeroTerms: true},
+			input:    NewEquation(NewVector(0, -1, 2), 3),
+			expected: "-x₂ + 2x₃ = 3",
+		},
+		{
+			name:     "all terms are zero",
+			f:        Formatter{SuppressZeroTerms: true},
+			input:    NewEquation(NewVector(0, 0), 3),
+			expected: "0 = 3",
+		},
+		{
+			name:     "ascii subscripts",
+			f:        Formatter{ASCIISubscripts: true},
+			input:    NewEquation(NewVector(1, 2), 3),
+			expected: "1x_1 + 2x_2 = 3",
+		},
+		{
+			name:     "plus sign",
+			f:        Formatter{Sign: SignAlways, SuppressUnitCoefficients: true},
+			input:    NewEquation(NewVector(1, -2), 3),
+			expected: "+x₁ - 2x₂ = +3",
+		},
+		{
+			name:     "fractions",
+			f:        Formatter{Fractions: true},
+			input:    NewEquation(NewVector(0.5, -1.0/3), 2.0/3),
+			expected: "1/2x₁ - 1/3x₂ = 2/3",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.f.Equation(test.input); actual != test.expected {
+			t.Errorf("%s: expected '%v', but got '%v'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestFormatterSystemAndParameterizationFunctions(t *testing.T) {
+	f := Formatter{Verb: 'f', Precision: 1, ASCIISubscripts: true}
+
+	s := NewSystem(NewEquation(NewVector(1, 2), 3), NewEquation(NewVector(4, 5), 6))
+	if actual, expected := f.System(s), "{ 1.0x_1 + 2.0x_2 = 3.0, 4.0x_1 + 5.0x_2 = 6.0 }"; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+
+	p := Parameterization{
+		Basepoint:        NewVector(1.25, 0, 0),
+		DirectionVectors: []Vector{NewVector(-1, 1, 0), NewVector(2, 0, 1), NewVector(0.5, 0, 0)},
+	}
+	if actual, expected := f.Parameterization(p), "{ x_1 = 1.2 - t + 2.0s + 0.5t_1, x_2 = t, x_3 = s }"; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+
+	if actual, expected := f.Vector(NewVector(1, 2)), "[1.0, 2.0]"; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestFormatterQuaternionFunction(t *testing.T) {
+	q := NewQuaternion(math.Copysign(0, -1), -1.0/3, 0.5, 2)
+
+	tests := []struct {
+		name     string
+		f        Formatter
+		expected string
+	}{
+		{name: "default", expected: "0 - 0.3333333333333333i + 0.5j + 2k"},
+		{name: "precision", f: Formatter{Verb: 'f', Precision: 2}, expected: "0.00 - 0.33i + 0.50j + 2.00k"},
+		{name: "fractions", f: Formatter{Fractions: true}, expected: "0 - 1/3i + 1/2j + 2k"},
+	}
+
+	for _, test := range tests {
+		if actual := test.f.Quaternion(q); actual != test.expected {
+			t.Errorf("%s: expected '%v', but got '%v'", test.name, test.expected, actual)
+		}
+	}
+	if actual := q.String(); actual != tests[0].expected {
+		t.Errorf("expected String to write '%v', but got '%v'", tests[0].expected, actual)
+	}
+}
+
+func TestFormatterVerbs(t *testing.T) {
+	e := NewEquation(NewVector(1, 0, -2.125), 3)
+	tests := []struct {
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{format: "%v", value: e, expected: "1x₁ + 0x₂ - 2.125x₃ = 3"},
+		{format: "%s", value: e, expected: "1x₁ + 0x₂ - 2.125x₃ = 3"},
+		{format: "%+v", value: e, expected: "x₁ - 2.125x₃ = 3"},
+		{format: "%.1f", value: e, expected: "1.0x₁ + 0.0x₂ - 2.1x₃ = 3.0"},
+		{format: "%+.2f", value: e, expected: "x₁ - 2.12x₃ = 3.00"},
+		{format: "%#v", value: e, expected: "linear.Equation{NormalVector:linear.Vector{1, 0, -2.125}, ConstantTerm:3}"},
+		{format: "%d", value: e, expected: "%!d(linear.Equation=1x₁ + 0x₂ - 2.125x₃ = 3)"},
+		{format: "%.2f", value: NewVector(1, 2.5), expected: "[1.00, 2.50]"},
+		{format: "%12v", value: NewVector(1, 2.5), expected: "    [1, 2.5]"},
+		{format: "%-12v|", value: NewVector(1, 2.5), expected: "[1, 2.5]    |"},
+		{format: "%#v", value: NewVector(1, 2.5), expected: "linear.Vector{1, 2.5}"},
+		{format: "%+v", value: NewSystem(NewEquation(NewVector(1, 0), 1)), expected: "{ x₁ = 1 }"},
+		{format: "%#v", value: NewSystem(NewEquation(NewVector(1), 1)), expected: "linear.System{linear.Equation{NormalVector:linear.Vector{1}, ConstantTerm:1}}"},
+		{
+			format:   "%.1f",
+			value:    Parameterization{Basepoint: NewVector(1, 0), DirectionVectors: []Vector{NewVector(-1.5, 1)}},
+			expected: "{ x₁ = 1.0 - 1.5t, x₂ = t }",
+		},
+		{
+			format:   "%#v",
+			value:    Parameterization{Basepoint: NewVector(1, 0), DirectionVectors: []Vector{NewVector(-1, 1)}},
+			expected: "linear.Parameterization{Basepoint:linear.Vector{1, 0}, DirectionVectors:[]linear.Vector{linear.Vector{-1, 1}}}",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := fmt.Sprintf(test.format, test.value); actual != test.expected {
+			t.Errorf("for '%v', expected '%v', but got '%v'", test.format, test.expected, actual)
+		}
+	}
+}
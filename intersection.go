@@ -0,0 +1,148 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// IntersectionKind describes the shape of the intersection of a set of hyperplanes.
+type IntersectionKind int
+
+const (
+	// NoIntersection is returned when there is no point which lies on all of the hyperplanes.
+	NoIntersection IntersectionKind = iota
+	// PointIntersection is returned when the hyperplanes meet at a single point.
+	PointIntersection
+	// AffineSubspaceIntersection is returned when the hyperplanes meet at infinitely many points, e.g. along a line, a
+	// plane, or a higher dimensional flat.
+	AffineSubspaceIntersection
+)
+
+func (k IntersectionKind) String() string {
+	switch k {
+	case NoIntersection:
+		return "none"
+	case PointIntersection:
+		return "point"
+	case AffineSubspaceIntersection:
+		return "affine subspace"
+	}
+	return "unknown"
+}
+
+// Intersection is the set of points which lie on all of a set of hyperplanes.
+type Intersection struct {
+	Kind IntersectionKind
+	// Dimension is the dimension of the intersection, i.e. 0 for a point, 1 for a line, 2 for a plane. It is -1 if
+	// there is no intersection.
+	Dimension int
+	// Parameterization describes the points in the intersection. For a point intersection, the basepoint is the point
+	// and there are no direction vectors. It is empty if there is no intersection.
+	Parameterization Parameterization
+}
+
+// Point returns the point of intersection. ok is false if the intersection is not a single point.
+func (i Intersection) Point() (point Vector, ok bool) {
+	if i.Kind != PointIntersection {
+		return Vector{}, false
+	}
+	return i.Parameterization.Basepoint, true
+}
+
+func (i Intersection) String() string {
+	switch i.Kind {
+	case NoIntersection:
+		return "no intersection"
+	case PointIntersection:
+		return fmt.Sprintf("point %v", i.Parameterization.Basepoint)
+	}
+	buf := bytes.NewBufferString(fmt.Sprintf("%d-dimensional affine subspace ", i.Dimension))
+	buf.WriteString(i.Parameterization.String())
+	return buf.String()
+}
+
+// Intersect calculates the intersection of any number of hyperplanes which all have the same dimensions, by solving
+// them as a system of equations.
+func Intersect(equations ...Equation) (Intersection, error) {
+	if len(equations) == 0 {
+		return Intersection{}, errors.New("at least one equation is required to calculate an intersection")
+	}
+	p, noSolution, err := NewSystem(equations...).SolutionSet()
+	if err != nil {
+		return Intersection{}, err
+	}
+	if noSolution {
+		return Intersection{Kind: NoIntersection, Dimension: -1}, nil
+	}
+	kind := PointIntersection
+	if len(p.DirectionVectors) > 0 {
+		kind = AffineSubspaceIntersection
+	}
+	return Intersection{
+		Kind:             kind,
+		Dimension:        len(p.DirectionVectors),
+		Parameterization: p,
+	}, nil
+}
+
+// PlaneIntersectionWith calculates the intersection with another 3D plane. If the planes intersect in a line, the line
+// is returned as a Parameterization with a basepoint and one direction vector.
+// intersects is set to false if the planes are parallel.
+// equal is set to true if the planes are equal, in which case the returned Parameterization has two direction vectors
+// and describes the whole plane.
+func (l1 Equation) PlaneIntersectionWith(l2 Equation) (line Parameterization, intersects bool, equal bool, err error) {
+	if len(l1.NormalVector) != 3 || len(l2.NormalVector) != 3 {
+		return Parameterization{}, false, false, fmt.Errorf("the PlaneIntersectionWith function requires that both planes have 3 dimensions, but the base plane has %d dimensions and l2 has %d dimensions", len(l1.NormalVector), len(l2.NormalVector))
+	}
+	i, err := Intersect(l1, l2)
+	if err != nil || i.Kind == NoIntersection {
+		return Parameterization{}, false, false, err
+	}
+	return i.Parameterization, true, i.Dimension == 2, nil
+}
+
+// LineIntersectionWith calculates the intersection of the hyperplane with a line, where the line is a Parameterization
+// with a basepoint and one direction vector.
+// intersects is set to false if the line is parallel to the hyperplane and not on it.
+// contains is set to true if the line lies in the hyperplane, in which case the basepoint of the line is returned.
+func (l1 Equation) LineIntersectionWith(line Parameterization) (point Vector, intersects bool, contains bool, err error) {
+	if len(line.DirectionVectors) != 1 {
+		return Vector{}, false, false, fmt.Errorf("a line must have exactly one direction vector, but the parameterization has %d", len(line.DirectionVectors))
+	}
+	direction := line.DirectionVectors[0]
+	if len(l1.NormalVector) != len(line.Basepoint) || len(l1.NormalVector) != len(direction) {
+		return Vector{}, false, false, fmt.Errorf("the hyperplane has %d dimensions, but the line has %d dimensions", len(l1.NormalVector), len(line.Basepoint))
+	}
+
+	// Substituting the line x = b + td into n·x = c gives the single variable equation (n·d)t = c - n·b.
+	nd, _ := l1.NormalVector.DotProduct(direction)
+	nb, _ := l1.NormalVector.DotProduct(line.Basepoint)
+	t, noSolution, infiniteSolutions, err := NewSystem(NewEquation(NewVector(nd), l1.ConstantTerm-nb)).Solve()
+	if err != nil || noSolution {
+		return Vector{}, false, false, err
+	}
+	if infiniteSolutions {
+		return line.Basepoint, true, true, nil
+	}
+	point, _ = line.Basepoint.Add(direction.Scale(t[0]))
+	return point, true, false, nil
+}
+
+// ThreePlaneIntersection calculates the intersection of three 3D planes. The intersection is returned as a
+// Parameterization with no direction vectors if the planes meet at a single point, one direction vector if they meet
+// along a line, or two direction vectors if all three planes are equal.
+// intersects is set to false if there is no point which lies on all three planes, e.g. because two of the planes are
+// parallel, or the planes meet in pairs along three parallel lines.
+func ThreePlaneIntersection(p1, p2, p3 Equation) (intersection Parameterization, intersects bool, err error) {
+	for i, p := range []Equation{p1, p2, p3} {
+		if len(p.NormalVector) != 3 {
+			return Parameterization{}, false, fmt.Errorf("the ThreePlaneIntersection function requires that all planes have 3 dimensions, but plane %d has %d dimensions", i+1, len(p.NormalVector))
+		}
+	}
+	i, err := Intersect(p1, p2, p3)
+	if err != nil || i.Kind == NoIntersection {
+		return Parameterization{}, false, err
+	}
+	return i.Parameterization, true, nil
+}
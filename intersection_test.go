@@ -0,0 +1,406 @@
+package linear
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// assertParameterizationLiesOn checks that the basepoint and every direction from it lie on each of the equations.
+func assertParameterizationLiesOn(t *testing.T, name string, p Parameterization, equations ...Equation) {
+	for _, e := range equations {
+		if d, _ := e.SignedDistanceTo(p.Basepoint); !tolerance.IsWithin(d, 0, DefaultTolerance) {
+			t.Errorf("%s: expected the basepoint %v to be on %v, but it was %v away", name, p.Basepoint, e, d)
+		}
+		for _, direction := range p.DirectionVectors {
+			if orthogonal, _ := direction.IsOrthogonalTo(e.NormalVector); !orthogonal {
+				t.Errorf("%s: expected the direction vector %v to be parallel to %v", name, direction, e)
+			}
+		}
+	}
+}
+
+func TestPlaneIntersectionWithFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		a                    Equation
+		b                    Equation
+		intersects           bool
+		equal                bool
+		expectedDirection    Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:              "xy and xz planes meet at the x axis",
+			a:                 NewEquation(NewVector(0, 0, 1), 0),
+			b:                 NewEquation(NewVector(0, 1, 0), 0),
+			intersects:        true,
+			expectedDirection: NewVector(1, 0, 0),
+		},
+		{
+			name:              "Udacity example",
+			a:                 NewEquation(NewVector(-0.412, 3.806, 0.728), -3.46),
+			b:                 NewEquation(NewVector(1.03, -9.515, -1.82), 8.65),
+			intersects:        true,
+			equal:             true,
+			expectedDirection: nil,
+		},
+		{
+			name:       "parallel planes",
+			a:          NewEquation(NewVector(1, 1, 1), 1),
+			b:          NewEquation(NewVector(2, 2, 2), 5),
+			intersects: false,
+		},
+		{
+			name:              "general planes",
+			a:                 NewEquation(NewVector(1, 2, 3), 4),
+			b:                 NewEquation(NewVector(-1, 0, 2), 1),
+			intersects:        true,
+			expectedDirection: NewVector(4, -5, 2),
+		},
+		{
+			name:                 "2D lines",
+			a:                    NewEquation(NewVector(1, 2), 4),
+			b:                    NewEquation(NewVector(-1, 0, 2), 1),
+			expectedErrorMessage: "the PlaneIntersectionWith function requires that both planes have 3 dimensions, but the base plane has 2 dimensions and l2 has 3 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, intersects, equal, err := test.a.PlaneIntersectionWith(test.b)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if intersects != test.intersects {
+			t.Errorf("%s: expected intersects to be %v, but got %v", test.name, test.intersects, intersects)
+		}
+		if equal != test.equal {
+			t.Errorf("%s: expected equal to be %v, but got %v", test.name, test.equal, equal)
+		}
+		if !intersects {
+			continue
+		}
+		assertParameterizationLiesOn(t, test.name, actual, test.a, test.b)
+		if test.expectedDirection != nil {
+			if len(actual.DirectionVectors) != 1 {
+				t.Fatalf("%s: expected a line, but got %v", test.name, actual)
+			}
+			if parallel, _ := actual.DirectionVectors[0].IsParallelTo(test.expectedDirection); !parallel {
+				t.Errorf("%s: expected the direction to be parallel to %v, but got %v", test.name, test.expectedDirection, actual.DirectionVectors[0])
+			}
+		}
+	}
+}
+
+func TestLineIntersectionWithFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		e                    Equation
+		line                 Parameterization
+		expected             Vector
+		intersects           bool
+		contains             bool
+		expectedErrorMessage string
+	}{
+		{
+			name:       "z axis meets the plane z = 3",
+			e:          NewEquation(NewVector(0, 0, 1), 3),
+			line:       Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(0, 0, 1)}},
+			expected:   NewVector(0, 0, 3),
+			intersects: true,
+		},
+		{
+			name:       "diagonal line meets the plane x + y + z = 6",
+			e:          NewEquation(NewVector(1, 1, 1), 6),
+			line:       Parameterization{Basepoint: NewVector(1, 0, 0), DirectionVectors: []Vector{NewVector(1, 1, 0)}},
+			expected:   NewVector(3.5, 2.5, 0),
+			intersects: true,
+		},
+		{
+			name:       "line parallel to the plane",
+			e:          NewEquation(NewVector(0, 0, 1), 3),
+			line:       Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(1, 1, 0)}},
+			intersects: false,
+		},
+		{
+			name:       "line in the plane",
+			e:          NewEquation(NewVector(0, 0, 1), 3),
+			line:       Parameterization{Basepoint: NewVector(1, 2, 3), DirectionVectors: []Vector{NewVector(1, 1, 0)}},
+			expected:   NewVector(1, 2, 3),
+			intersects: true,
+			contains:   true,
+		},
+		{
+			name:                 "not a line",
+			e:                    NewEquation(NewVector(0, 0, 1), 3),
+			line:                 Parameterization{Basepoint: NewVector(1, 2, 3)},
+			expectedErrorMessage: "a line must have exactly one direction vector, but the parameterization has 0",
+		},
+		{
+			name:                 "mismatched dimensions",
+			e:                    NewEquation(NewVector(0, 1), 3),
+			line:                 Parameterization{Basepoint: NewVector(1, 2, 3), DirectionVectors: []Vector{NewVector(1, 1, 0)}},
+			expectedErrorMessage: "the hyperplane has 2 dimensions, but the line has 3 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, intersects, contains, err := test.e.LineIntersectionWith(test.line)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if intersects != test.intersects {
+			t.Errorf("%s: expected intersects to be %v, but got %v", test.name, test.intersects, intersects)
+		}
+		if contains != test.contains {
+			t.Errorf("%s: expected contains to be %v, but got %v", test.name, test.contains, contains)
+		}
+		if intersects && !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestThreePlaneIntersectionFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		planes               []Equation
+		intersects           bool
+		directions           int
+		expectedPoint        Vector
+		expectedErrorMessage string
+	}{
+		{
+			name: "single point",
+			planes: []Equation{
+				NewEquation(NewVector(1, 1, 1), 6),
+				NewEquation(NewVector(0, 1, 1), 5),
+				NewEquation(NewVector(0, 0, 1), 3),
+			},
+			intersects:    true,
+			directions:    0,
+			expectedPoint: NewVector(1, 2, 3),
+		},
+		{
+			name: "planes through a common line",
+			planes: []Equation{
+				NewEquation(NewVector(1, 0, 0), 1),
+				NewEquation(NewVector(0, 1, 0), 2),
+				NewEquation(NewVector(1, 1, 0), 3),
+			},
+			intersects: true,
+			directions: 1,
+		},
+		{
+			name: "all planes equal",
+			planes: []Equation{
+				NewEquation(NewVector(1, 1, 1), 1),
+				NewEquation(NewVector(2, 2, 2), 2),
+				NewEquation(NewVector(-1, -1, -1), -1),
+			},
+			intersects: true,
+			directions: 2,
+		},
+		{
+			name: "two parallel planes",
+			planes: []Equation{
+				NewEquation(NewVector(1, 1, 1), 1),
+				NewEquation(NewVector(1, 1, 1), 2),
+				NewEquation(NewVector(1, 0, 0), 0),
+			},
+			intersects: false,
+		},
+		{
+			name: "planes meet in pairs along parallel lines",
+			planes: []Equation{
+				NewEquation(NewVector(1, 0, 0), 0),
+				NewEquation(NewVector(0, 1, 0), 0),
+				NewEquation(NewVector(1, 1, 0), 1),
+			},
+			intersects: false,
+		},
+		{
+			name: "2D line",
+			planes: []Equation{
+				NewEquation(NewVector(1, 0, 0), 0),
+				NewEquation(NewVector(0, 1), 0),
+				NewEquation(NewVector(1, 1, 0), 1),
+			},
+			expectedErrorMessage: "the ThreePlaneIntersection function requires that all planes have 3 dimensions, but plane 2 has 2 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, intersects, err := ThreePlaneIntersection(test.planes[0], test.planes[1], test.planes[2])
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), test.expectedErrorMessage) || test.expectedErrorMessage == "" {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if intersects != test.intersects {
+			t.Errorf("%s: expected intersects to be %v, but got %v", test.name, test.intersects, intersects)
+		}
+		if !intersects {
+			continue
+		}
+		if len(actual.DirectionVectors) != test.directions {
+			t.Errorf("%s: expected %d direction vectors, but got %v", test.name, test.directions, actual)
+		}
+		if test.expectedPoint != nil && !actual.Basepoint.Eq(test.expectedPoint) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expectedPoint, actual.Basepoint)
+		}
+		assertParameterizationLiesOn(t, test.name, actual, test.planes...)
+	}
+}
+
+func TestIntersectFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                []Equation
+		expectedKind         IntersectionKind
+		expectedDimension    int
+		expectedPoint        Vector
+		expectedErrorMessage string
+	}{
+		{
+			name: "2D lines meet at a point",
+			input: []Equation{
+				NewEquation(NewVector(1, 1), 3),
+				NewEquation(NewVector(1, -1), 1),
+			},
+			expectedKind:      PointIntersection,
+			expectedDimension: 0,
+			expectedPoint:     NewVector(2, 1),
+		},
+		{
+			name: "parallel 2D lines",
+			input: []Equation{
+				NewEquation(NewVector(1, 1), 3),
+				NewEquation(NewVector(1, 1), 1),
+			},
+			expectedKind:      NoIntersection,
+			expectedDimension: -1,
+		},
+		{
+			name: "single 3D plane",
+			input: []Equation{
+				NewEquation(NewVector(1, 1, 1), 3),
+			},
+			expectedKind:      AffineSubspaceIntersection,
+			expectedDimension: 2,
+		},
+		{
+			name: "4D hyperplanes meet in a plane",
+			input: []Equation{
+				NewEquation(NewVector(1, 0, 0, 0), 1),
+				NewEquation(NewVector(0, 1, 0, 1), 2),
+			},
+			expectedKind:      AffineSubspaceIntersection,
+			expectedDimension: 2,
+		},
+		{
+			name: "5D hyperplanes meet at a point",
+			input: []Equation{
+				NewEquation(NewVector(1, 0, 0, 0, 0), 1),
+				NewEquation(NewVector(0, 1, 0, 0, 0), 2),
+				NewEquation(NewVector(0, 0, 1, 0, 0), 3),
+				NewEquation(NewVector(0, 0, 0, 1, 0), 4),
+				NewEquation(NewVector(1, 0, 0, 0, 1), 6),
+			},
+			expectedKind:      PointIntersection,
+			expectedDimension: 0,
+			expectedPoint:     NewVector(1, 2, 3, 4, 5),
+		},
+		{
+			name: "more equations than dimensions",
+			input: []Equation{
+				NewEquation(NewVector(1, 0), 1),
+				NewEquation(NewVector(0, 1), 2),
+				NewEquation(NewVector(1, 1), 3),
+			},
+			expectedKind:      PointIntersection,
+			expectedDimension: 0,
+			expectedPoint:     NewVector(1, 2),
+		},
+		{
+			name:                 "no equations",
+			input:                []Equation{},
+			expectedErrorMessage: "at least one equation is required to calculate an intersection",
+		},
+		{
+			name: "mismatched dimensions",
+			input: []Equation{
+				NewEquation(NewVector(1, 0), 1),
+				NewEquation(NewVector(0, 1, 0), 2),
+			},
+			expectedErrorMessage: "all equations in a system need to have the same number of terms",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := Intersect(test.input...)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if actual.Kind != test.expectedKind {
+			t.Errorf("%s: expected kind %v, but got %v", test.name, test.expectedKind, actual.Kind)
+		}
+		if actual.Dimension != test.expectedDimension {
+			t.Errorf("%s: expected dimension %d, but got %d", test.name, test.expectedDimension, actual.Dimension)
+		}
+		point, ok := actual.Point()
+		if ok != (test.expectedKind == PointIntersection) {
+			t.Errorf("%s: expected Point() to return ok %v, but got %v", test.name, !ok, ok)
+		}
+		if ok && !point.Eq(test.expectedPoint) {
+			t.Errorf("%s: expected point %v, but got %v", test.name, test.expectedPoint, point)
+		}
+		if actual.Kind != NoIntersection {
+			assertParameterizationLiesOn(t, test.name, actual.Parameterization, test.input...)
+		}
+	}
+}
+
+func TestIntersectionStringRepresentation(t *testing.T) {
+	tests := []struct {
+		input    Intersection
+		expected string
+	}{
+		{
+			input:    Intersection{Kind: NoIntersection, Dimension: -1},
+			expected: "no intersection",
+		},
+		{
+			input:    Intersection{Kind: PointIntersection, Parameterization: Parameterization{Basepoint: NewVector(1, 2)}},
+			expected: "point [1, 2]",
+		},
+		{
+			input: Intersection{
+				Kind:      AffineSubspaceIntersection,
+				Dimension: 1,
+				Parameterization: Parameterization{
+					Basepoint:        NewVector(1, 0),
+					DirectionVectors: []Vector{NewVector(0, 1)},
+				},
+			},
+			expected: "1-dimensional affine subspace { x₁ = 1, x₂ = t }",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.input.String(); actual != test.expected {
+			t.Errorf("expected '%v', but got '%v'", test.expected, actual)
+		}
+	}
+}
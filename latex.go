@@ -0,0 +1,207 @@
+package linear
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// SystemEnvironment is the LaTeX environment used to lay out a system of equations.
+type SystemEnvironment int
+
+const (
+	// AlignedEnvironment lines up the equals signs of the equations, e.g. \begin{aligned} x_{1} &= 1 \\ x_{2} &= 2 \end{aligned}
+	AlignedEnvironment SystemEnvironment = iota
+	// CasesEnvironment groups the equations with a left brace, e.g. \begin{cases} x_{1} = 1 \\ x_{2} = 2 \end{cases}
+	CasesEnvironment
+)
+
+// LaTeXRenderer writes vectors, equations, systems and parameterizations as LaTeX, for use in a maths environment.
+type LaTeXRenderer struct {
+	// Precision is the number of digits after the decimal point. If it is negative, the smallest number of digits
+	// which represents each value exactly is used.
+	Precision int
+	// Variables names the variables of equations and parameterizations. If it is nil, the variables are named x_{1},
+	// x_{2}, and so on.
+	Variables Variables
+	// Environment is the environment used to lay out systems of equations.
+	Environment SystemEnvironment
+}
+
+// NewLaTeXRenderer creates a renderer which writes numbers with as many digits as required, names variables x_{1},
+// x_{2}, and so on, and aligns systems of equations on the equals sign.
+func NewLaTeXRenderer() LaTeXRenderer {
+	return LaTeXRenderer{
+		Precision:   -1,
+		Environment: AlignedEnvironment,
+	}
+}
+
+// Number writes out a number, e.g. -1.5, or 1.2 \times 10^{-7} for numbers which are too large or small to write out
+// in full.
+func (r LaTeXRenderer) Number(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return `\mathrm{NaN}`
+	case math.IsInf(v, 1):
+		return `\infty`
+	case math.IsInf(v, -1):
+		return `-\infty`
+	}
+	f := Formatter{Verb: 'g', Precision: -1}
+	if r.Precision >= 0 {
+		f = Formatter{Verb: 'f', Precision: r.Precision}
+	}
+	s := f.Number(v)
+	if mantissa, exponent, ok := strings.Cut(s, "e"); ok {
+		e, _ := strconv.Atoi(exponent)
+		s = fmt.Sprintf(`%s \times 10^{%d}`, mantissa, e)
+	}
+	return s
+}
+
+// Vector writes out the vector as a column vector, e.g. \begin{pmatrix} 1 \\ 2 \end{pmatrix}
+func (r LaTeXRenderer) Vector(v Vector) string {
+	values := make([]string, len(v))
+	for i, value := range v {
+		values[i] = r.Number(value)
+	}
+	return pmatrix(values)
+}
+
+func pmatrix(rows []string) string {
+	return `\begin{pmatrix} ` + strings.Join(rows, ` \\ `) + ` \end{pmatrix}`
+}
+
+// Equation writes out the equation, leaving out terms with a coefficient of zero and writing coefficients of one as
+// just the variable name, e.g. 2x_{1} - x_{2} = 5
+func (r LaTeXRenderer) Equation(e Equation) string {
+	return r.equation(e, " = ")
+}
+
+func (r LaTeXRenderer) equation(e Equation, equals string) string {
+	terms := make([]latexTerm, len(e.NormalVector))
+	for i, coefficient := range e.NormalVector {
+		terms[i] = latexTerm{coefficient: coefficient, name: r.variableName(i)}
+	}
+	return r.sum(0, false, terms) + equals + r.Number(e.ConstantTerm)
+}
+
+// latexTerm is a coefficient multiplied by a variable.
+type latexTerm struct {
+	coefficient float64
+	name        string
+}
+
+// sum writes out the constant (if includeConstant is true and it is non-zero) followed by each of the terms which has
+// a non-zero coefficient. If there is nothing to write, it writes 0.
+func (r LaTeXRenderer) sum(constant float64, includeConstant bool, terms []latexTerm) string {
+	buf := bytes.Buffer{}
+	if includeConstant && !tolerance.IsWithin(constant, 0, DefaultTolerance) {
+		buf.WriteString(r.Number(constant))
+	}
+	for _, t := range terms {
+		if tolerance.IsWithin(t.coefficient, 0, DefaultTolerance) {
+			continue
+		}
+		switch {
+		case buf.Len() > 0:
+			buf.WriteString(operator(t.coefficient))
+		case t.coefficient < 0:
+			buf.WriteString("-")
+		}
+		if !tolerance.IsWithin(math.Abs(t.coefficient), 1, DefaultTolerance) {
+			buf.WriteString(r.Number(math.Abs(t.coefficient)))
+		}
+		buf.WriteString(t.name)
+	}
+	if buf.Len() == 0 {
+		return "0"
+	}
+	return buf.String()
+}
+
+// System writes out each equation in the system using the configured environment.
+func (r LaTeXRenderer) System(s System) string {
+	equals, environment := " &= ", "aligned"
+	if r.Environment == CasesEnvironment {
+		equals, environment = " = ", "cases"
+	}
+	equations := make([]string, len(s))
+	for i, e := range s {
+		equations[i] = r.equation(e, equals)
+	}
+	return `\begin{` + environment + `} ` + strings.Join(equations, ` \\ `) + ` \end{` + environment + `}`
+}
+
+// AugmentedMatrix writes out the coefficients of the system as a matrix, with the constant terms separated from the
+// coefficients by a vertical bar, e.g. \left(\begin{array}{cc|c} 1 & 2 & 3 \\ 4 & 5 & 6 \end{array}\right)
+func (r LaTeXRenderer) AugmentedMatrix(s System) string {
+	columns := 0
+	if len(s) > 0 {
+		columns = len(s[0].NormalVector)
+	}
+	rows := make([]string, len(s))
+	for i, e := range s {
+		values := make([]string, 0, len(e.NormalVector)+1)
+		for _, coefficient := range e.NormalVector {
+			values = append(values, r.Number(coefficient))
+		}
+		values = append(values, r.Number(e.ConstantTerm))
+		rows[i] = strings.Join(values, " & ")
+	}
+	return `\left(\begin{array}{` + strings.Repeat("c", columns) + `|c} ` + strings.Join(rows, ` \\ `) + ` \end{array}\right)`
+}
+
+// Parameterization writes out the set of points as a vector equation, followed by the values the free variables can
+// take, e.g. \begin{pmatrix} x_{1} \\ x_{2} \end{pmatrix} = \begin{pmatrix} 1 \\ 0 \end{pmatrix} + t \begin{pmatrix} -1 \\ 1 \end{pmatrix}, \quad t \in \mathbb{R}
+func (r LaTeXRenderer) Parameterization(p Parameterization) string {
+	names := make([]string, len(p.Basepoint))
+	for i := range names {
+		names[i] = r.variableName(i)
+	}
+	buf := bytes.NewBufferString(pmatrix(names))
+	buf.WriteString(" = ")
+	buf.WriteString(r.Vector(p.Basepoint))
+	freeVariables := make([]string, len(p.DirectionVectors))
+	for i, d := range p.DirectionVectors {
+		freeVariables[i] = latexName(getFreeVariableName(i))
+		buf.WriteString(" + ")
+		buf.WriteString(freeVariables[i])
+		buf.WriteString(" ")
+		buf.WriteString(r.Vector(d))
+	}
+	if len(freeVariables) > 0 {
+		buf.WriteString(`, \quad `)
+		buf.WriteString(strings.Join(freeVariables, ", "))
+		buf.WriteString(` \in \mathbb{R}`)
+	}
+	return buf.String()
+}
+
+func (r LaTeXRenderer) variableName(index int) string {
+	if r.Variables == nil {
+		return latexName(defaultVariableName(index))
+	}
+	return latexName(r.Variables.name(index))
+}
+
+// latexName converts a variable name to LaTeX. Trailing subscript digits are converted to a subscript, e.g. x₁ becomes
+// x_{1}, names with more than one letter are written upright, e.g. \mathrm{price}, and underscores are escaped.
+func latexName(name string) string {
+	base := strings.TrimRightFunc(name, func(r rune) bool { return r >= '₀' && r <= '₉' })
+	subscript := strings.Map(func(r rune) rune { return r - '₀' + '0' }, name[len(base):])
+	if len([]rune(base)) > 1 {
+		base = `\mathrm{` + strings.ReplaceAll(base, "_", `\_`) + `}`
+	} else {
+		base = strings.ReplaceAll(base, "_", `\_`)
+	}
+	if subscript != "" {
+		return base + "_{" + subscript + "}"
+	}
+	return base
+}
@@ -0,0 +1,116 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLaTeXRendererNumberFunction(t *testing.T) {
+	tests := []struct {
+		precision int
+		input     float64
+		expected  string
+	}{
+		{precision: -1, input: 1, expected: "1"},
+		{precision: -1, input: -1.5, expected: "-1.5"},
+		{precision: -1, input: 0.30000000000000004, expected: "0.30000000000000004"},
+		{precision: 2, input: 0.30000000000000004, expected: "0.30"},
+		{precision: 0, input: 2.5, expected: "2"},
+		{precision: 2, input: -0.001, expected: "0.00"},
+		{precision: -1, input: math.Copysign(0, -1), expected: "0"},
+		{precision: -1, input: 1.2e-7, expected: `1.2 \times 10^{-7}`},
+		{precision: -1, input: 3e21, expected: `3 \times 10^{21}`},
+		{precision: -1, input: math.Inf(-1), expected: `-\infty`},
+		{precision: -1, input: math.NaN(), expected: `\mathrm{NaN}`},
+	}
+
+	for _, test := range tests {
+		r := NewLaTeXRenderer()
+		r.Precision = test.precision
+		if actual := r.Number(test.input); actual != test.expected {
+			t.Errorf("for %v with precision %d, expected '%v', but got '%v'", test.input, test.precision, test.expected, actual)
+		}
+	}
+}
+
+func TestLaTeXRendererVectorAndEquationFunctions(t *testing.T) {
+	r := NewLaTeXRenderer()
+
+	if actual, expected := r.Vector(NewVector(1, -2.5, 0)), `\begin{pmatrix} 1 \\ -2.5 \\ 0 \end{pmatrix}`; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+
+	tests := []struct {
+		input    Equation
+		expected string
+	}{
+		{input: NewEquation(NewVector(2, -1), 5), expected: "2x_{1} - x_{2} = 5"},
+		{input: NewEquation(NewVector(-1, 0, 3.5), -2), expected: "-x_{1} + 3.5x_{3} = -2"},
+		{input: NewEquation(NewVector(0, 0), 0), expected: "0 = 0"},
+	}
+
+	for _, test := range tests {
+		if actual := r.Equation(test.input); actual != test.expected {
+			t.Errorf("for %v, expected '%v', but got '%v'", test.input, test.expected, actual)
+		}
+	}
+
+	named := NewLaTeXRenderer()
+	named.Variables, _ = NewVariables("price", "q", "unit_tax")
+	if actual, expected := named.Equation(NewEquation(NewVector(1, 2, -3), 4)), `\mathrm{price} + 2q - 3\mathrm{unit\_tax} = 4`; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestLaTeXRendererSystemFunctions(t *testing.T) {
+	s := NewSystem(
+		NewEquation(NewVector(1, 2), 3),
+		NewEquation(NewVector(0, -1), 0.5),
+	)
+
+	aligned := NewLaTeXRenderer()
+	if actual, expected := aligned.System(s), `\begin{aligned} x_{1} + 2x_{2} &= 3 \\ -x_{2} &= 0.5 \end{aligned}`; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+
+	cases := NewLaTeXRenderer()
+	cases.Environment = CasesEnvironment
+	if actual, expected := cases.System(s), `\begin{cases} x_{1} + 2x_{2} = 3 \\ -x_{2} = 0.5 \end{cases}`; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+
+	if actual, expected := aligned.AugmentedMatrix(s), `\left(\begin{array}{cc|c} 1 & 2 & 3 \\ 0 & -1 & 0.5 \end{array}\right)`; actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestLaTeXRendererParameterizationFunction(t *testing.T) {
+	r := NewLaTeXRenderer()
+
+	tests := []struct {
+		input    Parameterization
+		expected string
+	}{
+		{
+			input:    Parameterization{Basepoint: NewVector(1, 2)},
+			expected: `\begin{pmatrix} x_{1} \\ x_{2} \end{pmatrix} = \begin{pmatrix} 1 \\ 2 \end{pmatrix}`,
+		},
+		{
+			input: Parameterization{
+				Basepoint:        NewVector(1, 0, 0),
+				DirectionVectors: []Vector{NewVector(-1, 1, 0), NewVector(2, 0, 1), NewVector(0, 0, 1)},
+			},
+			expected: `\begin{pmatrix} x_{1} \\ x_{2} \\ x_{3} \end{pmatrix} = \begin{pmatrix} 1 \\ 0 \\ 0 \end{pmatrix}` +
+				` + t \begin{pmatrix} -1 \\ 1 \\ 0 \end{pmatrix}` +
+				` + s \begin{pmatrix} 2 \\ 0 \\ 1 \end{pmatrix}` +
+				` + t_{1} \begin{pmatrix} 0 \\ 0 \\ 1 \end{pmatrix}` +
+				`, \quad t, s, t_{1} \in \mathbb{R}`,
+		},
+	}
+
+	for _, test := range tests {
+		if actual := r.Parameterization(test.input); actual != test.expected {
+			t.Errorf("for %v, expected '%v', but got '%v'", test.input, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// LineKind determines which values of the parameter t are part of a Line.
+type LineKind int
+
+const (
+	// InfiniteLine extends forever in both directions, so t can take any value.
+	InfiniteLine LineKind = iota
+	// Ray starts at the basepoint and extends forever in the direction of the direction vector, so t ≥ 0.
+	Ray
+	// Segment starts at the basepoint and ends at the basepoint plus the direction vector, so 0 ≤ t ≤ 1.
+	Segment
+)
+
+func (k LineKind) String() string {
+	switch k {
+	case InfiniteLine:
+		return "line"
+	case Ray:
+		return "ray"
+	case Segment:
+		return "segment"
+	}
+	return "unknown"
+}
+
+// bounds returns the minimum and maximum values of t.
+func (k LineKind) bounds() (min float64, max float64) {
+	switch k {
+	case Ray:
+		return 0, math.Inf(1)
+	case Segment:
+		return 0, 1
+	}
+	return math.Inf(-1), math.Inf(1)
+}
+
+// Line is a line, ray or line segment in any number of dimensions, described by the parametric form
+// basepoint + t * direction.
+type Line struct {
+	Basepoint Vector
+	Direction Vector
+	Kind      LineKind
+}
+
+// NewLine creates an infinite line through the basepoint in the given direction.
+func NewLine(basepoint Vector, direction Vector) (Line, error) {
+	return newLine(basepoint, direction, InfiniteLine)
+}
+
+// NewRay creates a ray which starts at the basepoint and extends in the given direction.
+func NewRay(basepoint Vector, direction Vector) (Line, error) {
+	return newLine(basepoint, direction, Ray)
+}
+
+// NewSegment creates the line segment between the points from and to.
+func NewSegment(from Vector, to Vector) (Line, error) {
+	if len(from) != len(to) {
+		return Line{}, fmt.Errorf("cannot create a segment because the points have different dimensions (%d and %d)", len(from), len(to))
+	}
+	direction, _ := to.Sub(from)
+	return newLine(from, direction, Segment)
+}
+
+func newLine(basepoint Vector, direction Vector, kind LineKind) (Line, error) {
+	if len(basepoint) != len(direction) {
+		return Line{}, fmt.Errorf("cannot create a %v because the basepoint and direction have different dimensions (%d and %d)", kind, len(basepoint), len(direction))
+	}
+	if direction.IsZeroVector() {
+		return Line{}, fmt.Errorf("cannot create a %v with a zero direction vector", kind)
+	}
+	return Line{Basepoint: basepoint, Direction: direction, Kind: kind}, nil
+}
+
+// NewLineFromEquation converts a 2D Equation into an infinite line.
+func NewLineFromEquation(e Equation) (Line, error) {
+	if len(e.NormalVector) != 2 {
+		return Line{}, fmt.Errorf("only equations with 2 dimensions can be converted to a line, but the equation has %d dimensions", len(e.NormalVector))
+	}
+	basepoint, ok := e.NonZeroValuePoint()
+	if !ok {
+		return Line{}, errors.New("the equation has a zero normal vector, so it does not describe a line")
+	}
+	// Rotate the normal vector by 90 degrees to get the direction.
+	return NewLine(basepoint, NewVector(-e.NormalVector[1], e.NormalVector[0]))
+}
+
+func (l Line) String() string {
+	p := l.Parameterization().String()
+	switch l.Kind {
+	case Ray:
+		return p + ", t ≥ 0"
+	case Segment:
+		return p + ", 0 ≤ t ≤ 1"
+	}
+	return p
+}
+
+// Parameterization converts the line to a Parameterization with a single direction vector. The Kind of the line is
+// not retained.
+func (l Line) Parameterization() Parameterization {
+	return Parameterization{
+		Basepoint:        l.Basepoint,
+		DirectionVectors: []Vector{l.Direction},
+	}
+}
+
+// Equation converts a 2D line to an Equation. The Kind of the line is not retained.
+func (l Line) Equation() (Equation, error) {
+	if len(l.Basepoint) != 2 {
+		return Equation{}, fmt.Errorf("only lines with 2 dimensions can be converted to an equation, but the line has %d dimensions", len(l.Basepoint))
+	}
+	end, _ := l.Basepoint.Add(l.Direction)
+	return NewLineThroughPoints(l.Basepoint, end)
+}
+
+// PointAt returns the point basepoint + t * direction. The Kind of the line is ignored, so that points beyond the end
+// of a ray or segment can be calculated.
+func (l Line) PointAt(t float64) Vector {
+	// No need to check the error, the dimensions of the basepoint and direction are the same.
+	p, _ := l.Basepoint.Add(l.Direction.Scale(t))
+	return p
+}
+
+// Contains determines whether the parameter t is part of the line, e.g. whether it's between 0 and 1 for a segment.
+func (l Line) Contains(t float64) bool {
+	min, max := l.Kind.bounds()
+	return t >= min-DefaultTolerance && t <= max+DefaultTolerance
+}
+
+func (l Line) clamp(t float64) float64 {
+	min, max := l.Kind.bounds()
+	return math.Max(min, math.Min(max, t))
+}
+
+// ClosestPointTo calculates the point on the line which is closest to the point v, and the value of t at that point.
+// For rays and segments, the point is limited to the part of the line which exists.
+func (l Line) ClosestPointTo(v Vector) (point Vector, t float64, err error) {
+	if len(v) != len(l.Basepoint) {
+		return Vector{}, 0, fmt.Errorf("the %v has %d dimensions, but the point has %d dimensions", l.Kind, len(l.Basepoint), len(v))
+	}
+	if l.Direction.IsZeroVector() {
+		return Vector{}, 0, fmt.Errorf("the %v has a zero direction vector", l.Kind)
+	}
+	offset, _ := v.Sub(l.Basepoint)
+	dp, _ := offset.DotProduct(l.Direction)
+	dd, _ := l.Direction.DotProduct(l.Direction)
+	t = l.clamp(dp / dd)
+	return l.PointAt(t), t, nil
+}
+
+// ClosestPoints calculates the closest point on the current line to l2, and the closest point on l2 to the current
+// line. For skew lines in 3D, the vector between the points is perpendicular to both lines. If the lines are parallel
+// there are infinitely many pairs of closest points, and one of them is returned. If the lines intersect, both points
+// are the same.
+func (l Line) ClosestPoints(l2 Line) (p1 Vector, p2 Vector, err error) {
+	if len(l.Basepoint) != len(l2.Basepoint) {
+		return Vector{}, Vector{}, fmt.Errorf("cannot calculate the closest points because the lines have different dimensions (%d and %d)", len(l.Basepoint), len(l2.Basepoint))
+	}
+	if l.Direction.IsZeroVector() || l2.Direction.IsZeroVector() {
+		return Vector{}, Vector{}, errors.New("cannot calculate the closest points because one of the lines has a zero direction vector")
+	}
+	// Minimise |(b1 + s*d1) - (b2 + t*d2)|², see Real-Time Collision Detection, Christer Ericson, section 5.1.9.
+	r, _ := l.Basepoint.Sub(l2.Basepoint)
+	a, _ := l.Direction.DotProduct(l.Direction)
+	b, _ := l.Direction.DotProduct(l2.Direction)
+	c, _ := l.Direction.DotProduct(r)
+	e, _ := l2.Direction.DotProduct(l2.Direction)
+	f, _ := l2.Direction.DotProduct(r)
+
+	var s float64
+	denominator := a*e - b*b
+	if parallel, _ := l.Direction.IsParallelTo(l2.Direction); !parallel {
+		s = l.clamp((b*f - c*e) / denominator)
+	}
+	t := (b*s + f) / e
+	if clamped := l2.clamp(t); clamped != t {
+		t = clamped
+		s = l.clamp((t*b - c) / a)
+	}
+	return l.PointAt(s), l2.PointAt(t), nil
+}
+
+// DistanceTo calculates the shortest distance between the current line and l2.
+func (l Line) DistanceTo(l2 Line) (float64, error) {
+	p1, p2, err := l.ClosestPoints(l2)
+	if err != nil {
+		return 0, err
+	}
+	return p1.EuclideanDistance(p2)
+}
+
+// IntersectionWith calculates the intersection of the line with the hyperplane e.
+// intersects is set to false if the line is parallel to the hyperplane and not on it, or if the intersection is
+// beyond the end of a ray or segment.
+// contains is set to true if the line lies in the hyperplane, in which case the basepoint is returned.
+func (l Line) IntersectionWith(e Equation) (point Vector, intersects bool, contains bool, err error) {
+	point, intersects, contains, err = e.LineIntersectionWith(l.Parameterization())
+	if err != nil || !intersects || contains {
+		return point, intersects, contains, err
+	}
+	// Check that the intersection is part of the ray or segment.
+	offset, _ := point.Sub(l.Basepoint)
+	dp, _ := offset.DotProduct(l.Direction)
+	dd, _ := l.Direction.DotProduct(l.Direction)
+	if !l.Contains(dp / dd) {
+		return Vector{}, false, false, nil
+	}
+	return point, true, false, nil
+}
@@ -0,0 +1,307 @@
+package linear
+
+import (
+	"math"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+func TestLineConstructors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		f                    func() (Line, error)
+		expected             Line
+		expectedErrorMessage string
+	}{
+		{
+			name:     "line",
+			f:        func() (Line, error) { return NewLine(NewVector(1, 2), NewVector(0, 1)) },
+			expected: Line{Basepoint: NewVector(1, 2), Direction: NewVector(0, 1), Kind: InfiniteLine},
+		},
+		{
+			name:     "ray",
+			f:        func() (Line, error) { return NewRay(NewVector(1, 2), NewVector(0, 1)) },
+			expected: Line{Basepoint: NewVector(1, 2), Direction: NewVector(0, 1), Kind: Ray},
+		},
+		{
+			name:     "segment",
+			f:        func() (Line, error) { return NewSegment(NewVector(1, 2, 3), NewVector(4, 6, 3)) },
+			expected: Line{Basepoint: NewVector(1, 2, 3), Direction: NewVector(3, 4, 0), Kind: Segment},
+		},
+		{
+			name:                 "zero direction",
+			f:                    func() (Line, error) { return NewLine(NewVector(1, 2), NewVector(0, 0)) },
+			expectedErrorMessage: "cannot create a line with a zero direction vector",
+		},
+		{
+			name:                 "segment between the same point",
+			f:                    func() (Line, error) { return NewSegment(NewVector(1, 2), NewVector(1, 2)) },
+			expectedErrorMessage: "cannot create a segment with a zero direction vector",
+		},
+		{
+			name:                 "mismatched dimensions",
+			f:                    func() (Line, error) { return NewRay(NewVector(1, 2), NewVector(0, 0, 1)) },
+			expectedErrorMessage: "cannot create a ray because the basepoint and direction have different dimensions (2 and 3)",
+		},
+		{
+			name:                 "segment with mismatched dimensions",
+			f:                    func() (Line, error) { return NewSegment(NewVector(1, 2), NewVector(0, 0, 1)) },
+			expectedErrorMessage: "cannot create a segment because the points have different dimensions (2 and 3)",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.f()
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if !actual.Basepoint.Eq(test.expected.Basepoint) || !actual.Direction.Eq(test.expected.Direction) || actual.Kind != test.expected.Kind {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestLineStringRepresentation(t *testing.T) {
+	tests := []struct {
+		input    Line
+		expected string
+	}{
+		{
+			input:    Line{Basepoint: NewVector(1, 0), Direction: NewVector(0, 1), Kind: InfiniteLine},
+			expected: "{ x₁ = 1, x₂ = t }",
+		},
+		{
+			input:    Line{Basepoint: NewVector(1, 0), Direction: NewVector(0, 1), Kind: Ray},
+			expected: "{ x₁ = 1, x₂ = t }, t ≥ 0",
+		},
+		{
+			input:    Line{Basepoint: NewVector(1, 0), Direction: NewVector(0, 1), Kind: Segment},
+			expected: "{ x₁ = 1, x₂ = t }, 0 ≤ t ≤ 1",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.input.String(); actual != test.expected {
+			t.Errorf("expected '%v', but got '%v'", test.expected, actual)
+		}
+	}
+}
+
+func TestLinePointAtFunction(t *testing.T) {
+	l, _ := NewSegment(NewVector(1, 1, 1), NewVector(3, 5, 1))
+	tests := []struct {
+		t        float64
+		expected Vector
+		contains bool
+	}{
+		{t: 0, expected: NewVector(1, 1, 1), contains: true},
+		{t: 0.5, expected: NewVector(2, 3, 1), contains: true},
+		{t: 1, expected: NewVector(3, 5, 1), contains: true},
+		{t: 2, expected: NewVector(5, 9, 1), contains: false},
+		{t: -1, expected: NewVector(-1, -3, 1), contains: false},
+	}
+
+	for _, test := range tests {
+		if actual := l.PointAt(test.t); !actual.Eq(test.expected) {
+			t.Errorf("for t = %v, expected %v, but got %v", test.t, test.expected, actual)
+		}
+		if actual := l.Contains(test.t); actual != test.contains {
+			t.Errorf("for t = %v, expected contains to be %v, but got %v", test.t, test.contains, actual)
+		}
+	}
+}
+
+func TestLineClosestPointToFunction(t *testing.T) {
+	line, _ := NewLine(NewVector(0, 0), NewVector(1, 0))
+	ray, _ := NewRay(NewVector(0, 0), NewVector(1, 0))
+	segment, _ := NewSegment(NewVector(0, 0), NewVector(2, 0))
+
+	tests := []struct {
+		name      string
+		l         Line
+		v         Vector
+		expected  Vector
+		expectedT float64
+	}{
+		{name: "line", l: line, v: NewVector(-3, 4), expected: NewVector(-3, 0), expectedT: -3},
+		{name: "ray before the start", l: ray, v: NewVector(-3, 4), expected: NewVector(0, 0), expectedT: 0},
+		{name: "ray after the start", l: ray, v: NewVector(3, 4), expected: NewVector(3, 0), expectedT: 3},
+		{name: "segment after the end", l: segment, v: NewVector(3, 4), expected: NewVector(2, 0), expectedT: 1},
+		{name: "segment in the middle", l: segment, v: NewVector(1, 4), expected: NewVector(1, 0), expectedT: 0.5},
+	}
+
+	for _, test := range tests {
+		actual, actualT, err := test.l.ClosestPointTo(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !actual.Eq(test.expected) || !tolerance.IsWithin(actualT, test.expectedT, DefaultTolerance) {
+			t.Errorf("%s: expected %v at t = %v, but got %v at t = %v", test.name, test.expected, test.expectedT, actual, actualT)
+		}
+	}
+
+	if _, _, err := line.ClosestPointTo(NewVector(1, 2, 3)); err == nil || err.Error() != "the line has 2 dimensions, but the point has 3 dimensions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLineClosestPointsFunction(t *testing.T) {
+	xAxis, _ := NewLine(NewVector(0, 0, 0), NewVector(1, 0, 0))
+	skew, _ := NewLine(NewVector(0, 0, 1), NewVector(0, 1, 0))
+	skewOffset, _ := NewLine(NewVector(5, 3, 2), NewVector(0, 2, 0))
+	parallel, _ := NewLine(NewVector(3, 0, 4), NewVector(-2, 0, 0))
+	crossing, _ := NewLine(NewVector(2, -1, 0), NewVector(0, 1, 0))
+	segment, _ := NewSegment(NewVector(3, 1, 1), NewVector(3, 2, 1))
+	ray, _ := NewRay(NewVector(-1, 0, 0), NewVector(-1, 0, 0))
+	ray2, _ := NewRay(NewVector(1, 0, 0), NewVector(1, 0, 0))
+
+	tests := []struct {
+		name     string
+		a        Line
+		b        Line
+		p1       Vector
+		p2       Vector
+		distance float64
+	}{
+		{
+			name:     "skew lines",
+			a:        xAxis,
+			b:        skew,
+			p1:       NewVector(0, 0, 0),
+			p2:       NewVector(0, 0, 1),
+			distance: 1,
+		},
+		{
+			name:     "skew lines away from the origin",
+			a:        xAxis,
+			b:        skewOffset,
+			p1:       NewVector(5, 0, 0),
+			p2:       NewVector(5, 0, 2),
+			distance: 2,
+		},
+		{
+			name:     "parallel lines",
+			a:        xAxis,
+			b:        parallel,
+			distance: 4,
+		},
+		{
+			name:     "crossing lines",
+			a:        xAxis,
+			b:        crossing,
+			p1:       NewVector(2, 0, 0),
+			p2:       NewVector(2, 0, 0),
+			distance: 0,
+		},
+		{
+			name:     "segment which doesn't reach the closest point of the line",
+			a:        xAxis,
+			b:        segment,
+			p1:       NewVector(3, 0, 0),
+			p2:       NewVector(3, 1, 1),
+			distance: math.Sqrt(2),
+		},
+		{
+			name:     "rays pointing away from each other",
+			a:        ray,
+			b:        ray2,
+			p1:       NewVector(-1, 0, 0),
+			p2:       NewVector(1, 0, 0),
+			distance: 2,
+		},
+	}
+
+	for _, test := range tests {
+		p1, p2, err := test.a.ClosestPoints(test.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if test.p1 != nil && (!p1.Eq(test.p1) || !p2.Eq(test.p2)) {
+			t.Errorf("%s: expected %v and %v, but got %v and %v", test.name, test.p1, test.p2, p1, p2)
+		}
+		distance, _ := test.a.DistanceTo(test.b)
+		if !tolerance.IsWithin(distance, test.distance, DefaultTolerance) {
+			t.Errorf("%s: expected distance %v, but got %v", test.name, test.distance, distance)
+		}
+	}
+
+	line2D, _ := NewLine(NewVector(0, 0), NewVector(1, 0))
+	if _, _, err := xAxis.ClosestPoints(line2D); err == nil {
+		t.Errorf("expected an error calculating the closest points of lines with different dimensions")
+	}
+}
+
+func TestLineIntersectionWithEquationFunction(t *testing.T) {
+	plane := NewEquation(NewVector(0, 0, 1), 3)
+	line, _ := NewLine(NewVector(0, 0, 0), NewVector(0, 0, 1))
+	ray, _ := NewRay(NewVector(0, 0, 0), NewVector(0, 0, -1))
+	shortSegment, _ := NewSegment(NewVector(0, 0, 0), NewVector(0, 0, 2))
+	longSegment, _ := NewSegment(NewVector(0, 0, 0), NewVector(0, 0, 6))
+	inPlane, _ := NewLine(NewVector(1, 1, 3), NewVector(1, 0, 0))
+
+	tests := []struct {
+		name       string
+		l          Line
+		expected   Vector
+		intersects bool
+		contains   bool
+	}{
+		{name: "line", l: line, expected: NewVector(0, 0, 3), intersects: true},
+		{name: "ray pointing away", l: ray, intersects: false},
+		{name: "segment too short", l: shortSegment, intersects: false},
+		{name: "segment crosses", l: longSegment, expected: NewVector(0, 0, 3), intersects: true},
+		{name: "line in the plane", l: inPlane, expected: NewVector(1, 1, 3), intersects: true, contains: true},
+	}
+
+	for _, test := range tests {
+		actual, intersects, contains, err := test.l.IntersectionWith(plane)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if intersects != test.intersects || contains != test.contains {
+			t.Errorf("%s: expected intersects %v and contains %v, but got %v and %v", test.name, test.intersects, test.contains, intersects, contains)
+		}
+		if intersects && !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestLineEquationConversion(t *testing.T) {
+	e := NewEquation(NewVector(2, 3), 6)
+	l, err := NewLineFromEquation(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tv := range []float64{-2, 0, 3} {
+		if d, _ := e.SignedDistanceTo(l.PointAt(tv)); !tolerance.IsWithin(d, 0, DefaultTolerance) {
+			t.Errorf("expected the point at t = %v to be on %v", tv, e)
+		}
+	}
+
+	back, err := l.Equation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eq, _ := back.Eq(e); !eq {
+		t.Errorf("expected %v, but got %v", e, back)
+	}
+
+	if _, err := NewLineFromEquation(NewEquation(NewVector(0, 0), 1)); err == nil {
+		t.Errorf("expected an error converting an equation with a zero normal vector")
+	}
+	if _, err := NewLineFromEquation(NewEquation(NewVector(1, 0, 0), 1)); err == nil {
+		t.Errorf("expected an error converting a 3D equation")
+	}
+	l3, _ := NewLine(NewVector(1, 0, 0), NewVector(1, 0, 0))
+	if _, err := l3.Equation(); err == nil {
+		t.Errorf("expected an error converting a 3D line")
+	}
+}
@@ -0,0 +1,111 @@
+package linear
+
+import "math"
+
+// determinant calculates the determinant of a square matrix using Gaussian elimination with partial pivoting. The
+// input is not modified.
+func determinant(matrix [][]float64) float64 {
+	n := len(matrix)
+	m := make([][]float64, n)
+	for i, row := range matrix {
+		m[i] = append([]float64{}, row...)
+	}
+
+	det := 1.0
+	for col := 0; col < n; col++ {
+		// Use the row with the largest value in the column as the pivot to reduce rounding errors.
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if m[pivot][col] == 0 {
+			return 0
+		}
+		if pivot != col {
+			m[pivot], m[col] = m[col], m[pivot]
+			det = -det
+		}
+		det *= m[col][col]
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for c := col; c < n; c++ {
+				m[row][c] -= factor * m[col][c]
+			}
+		}
+	}
+	return det
+}
+
+// invert calculates the inverse of a square matrix using Gauss-Jordan elimination with partial pivoting. ok is false
+// if the matrix is singular. A pivot is treated as zero when it is small relative to the largest value in its row of
+// the input, so that matrices with a small scale, e.g. a scaling by 1e-11, can still be inverted. The input is not
+// modified.
+func invert(matrix [][]float64) (inverse [][]float64, ok bool) {
+	n := len(matrix)
+	// Augment the matrix with the identity matrix.
+	m := make([][]float64, n)
+	// norms holds the largest absolute value in each row of the input.
+	norms := make([]float64, n)
+	for i, row := range matrix {
+		m[i] = make([]float64, 2*n)
+		copy(m[i], row)
+		m[i][n+i] = 1
+		for _, v := range row {
+			norms[i] = math.Max(norms[i], math.Abs(v))
+		}
+		if norms[i] == 0 {
+			return nil, false
+		}
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < DefaultTolerance*norms[pivot] {
+			return nil, false
+		}
+		m[pivot], m[col] = m[col], m[pivot]
+		norms[pivot], norms[col] = norms[col], norms[pivot]
+
+		scale := 1 / m[col][col]
+		for c := range m[col] {
+			m[col][c] *= scale
+		}
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			factor := m[row][col]
+			for c := range m[row] {
+				m[row][c] -= factor * m[col][c]
+			}
+		}
+	}
+
+	inverse = make([][]float64, n)
+	for i := range m {
+		inverse[i] = m[i][n:]
+	}
+	return inverse, true
+}
+
+// multiply calculates the matrix product of a and b, where the number of columns of a is the same as the number of
+// rows of b.
+func multiply(a, b [][]float64) [][]float64 {
+	op := make([][]float64, len(a))
+	for i := range a {
+		op[i] = make([]float64, len(b[0]))
+		for j := range b[0] {
+			for k := range b {
+				op[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return op
+}
@@ -0,0 +1,450 @@
+package linear
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MatrixMarketFormat is the layout of the entries in a Matrix Market file.
+type MatrixMarketFormat string
+
+const (
+	// MatrixMarketCoordinate lists the row, column and value of each non-zero entry.
+	MatrixMarketCoordinate MatrixMarketFormat = "coordinate"
+	// MatrixMarketArray lists the value of every entry in column-major order.
+	MatrixMarketArray MatrixMarketFormat = "array"
+)
+
+// MatrixMarketField is the type of the values in a Matrix Market file.
+type MatrixMarketField string
+
+const (
+	// MatrixMarketReal values are floating point numbers.
+	MatrixMarketReal MatrixMarketField = "real"
+	// MatrixMarketInteger values are whole numbers.
+	MatrixMarketInteger MatrixMarketField = "integer"
+	// MatrixMarketPattern files don't contain values, only the positions of the non-zero entries, which are read as 1.
+	// Only the coordinate format supports patterns.
+	MatrixMarketPattern MatrixMarketField = "pattern"
+)
+
+// MatrixMarketSymmetry describes which entries are stored in a Matrix Market file.
+type MatrixMarketSymmetry string
+
+const (
+	// MatrixMarketGeneral files store every entry.
+	MatrixMarketGeneral MatrixMarketSymmetry = "general"
+	// MatrixMarketSymmetric files store the entries on and below the diagonal of a symmetric matrix.
+	MatrixMarketSymmetric MatrixMarketSymmetry = "symmetric"
+)
+
+// MatrixMarketHeader is the banner on the first line of a Matrix Market file, e.g.
+// %%MatrixMarket matrix coordinate real general
+type MatrixMarketHeader struct {
+	Format   MatrixMarketFormat
+	Field    MatrixMarketField
+	Symmetry MatrixMarketSymmetry
+}
+
+func (h MatrixMarketHeader) String() string {
+	return fmt.Sprintf("%%%%MatrixMarket matrix %s %s %s", h.Format, h.Field, h.Symmetry)
+}
+
+func (h MatrixMarketHeader) validate() error {
+	switch h.Format {
+	case MatrixMarketCoordinate, MatrixMarketArray:
+	default:
+		return fmt.Errorf("the Matrix Market format %q is not supported", h.Format)
+	}
+	switch h.Field {
+	case MatrixMarketReal, MatrixMarketInteger:
+	case MatrixMarketPattern:
+		if h.Format != MatrixMarketCoordinate {
+			return errors.New("the Matrix Market pattern field can only be used with the coordinate format")
+		}
+	default:
+		return fmt.Errorf("the Matrix Market field %q is not supported", h.Field)
+	}
+	switch h.Symmetry {
+	case MatrixMarketGeneral, MatrixMarketSymmetric:
+	default:
+		return fmt.Errorf("the Matrix Market symmetry %q is not supported", h.Symmetry)
+	}
+	return nil
+}
+
+// SparseEntry is the value at a row and column of a sparse matrix. Rows and columns are numbered from zero.
+type SparseEntry struct {
+	Row    int
+	Column int
+	Value  float64
+}
+
+// SparseMatrix stores the non-zero entries of a matrix. If more than one entry has the same row and column, the
+// value at that position is their sum.
+type SparseMatrix struct {
+	Rows    int
+	Columns int
+	Entries []SparseEntry
+}
+
+// NewSparseMatrixFromSystem creates a sparse matrix from the non-zero coefficients of the system. The constant terms
+// are not included.
+func NewSparseMatrixFromSystem(s System) (SparseMatrix, error) {
+	if !s.AllEquationsHaveSameNumberOfTerms() {
+		return SparseMatrix{}, errors.New("all equations in the system need to have the same number of terms")
+	}
+	m := SparseMatrix{Rows: len(s), Entries: []SparseEntry{}}
+	if len(s) > 0 {
+		m.Columns = len(s[0].NormalVector)
+	}
+	for i, e := range s {
+		for j, value := range e.NormalVector {
+			if value != 0 {
+				m.Entries = append(m.Entries, SparseEntry{Row: i, Column: j, Value: value})
+			}
+		}
+	}
+	return m, nil
+}
+
+// NewSparseMatrixFromVector creates a sparse matrix with a single column from the non-zero values of the vector.
+func NewSparseMatrixFromVector(v Vector) SparseMatrix {
+	m := SparseMatrix{Rows: len(v), Columns: 1, Entries: []SparseEntry{}}
+	for i, value := range v {
+		if value != 0 {
+			m.Entries = append(m.Entries, SparseEntry{Row: i, Value: value})
+		}
+	}
+	return m
+}
+
+// Dense returns every entry of the matrix, where op[row][column].
+func (m SparseMatrix) Dense() [][]float64 {
+	op := make([][]float64, m.Rows)
+	for i := range op {
+		op[i] = make([]float64, m.Columns)
+	}
+	for _, e := range m.Entries {
+		op[e.Row][e.Column] += e.Value
+	}
+	return op
+}
+
+// System creates a system of equations where the matrix contains the coefficients, and constants contains the constant
+// term of each equation. If constants is nil, every constant term is zero.
+func (m SparseMatrix) System(constants Vector) (System, error) {
+	if constants == nil {
+		constants = make(Vector, m.Rows)
+	}
+	if len(constants) != m.Rows {
+		return System{}, fmt.Errorf("the matrix has %d rows, but there are %d constant terms", m.Rows, len(constants))
+	}
+	op := make(System, m.Rows)
+	for i, row := range m.Dense() {
+		op[i] = NewEquation(Vector(row), constants[i])
+	}
+	return op, nil
+}
+
+// Vector returns the values of a matrix which has a single column, e.g. a right hand side or a solution.
+func (m SparseMatrix) Vector() (Vector, error) {
+	if m.Columns != 1 {
+		return Vector{}, fmt.Errorf("only a matrix with 1 column can be converted to a vector, but the matrix has %d columns", m.Columns)
+	}
+	op := make(Vector, m.Rows)
+	for _, e := range m.Entries {
+		op[e.Row] += e.Value
+	}
+	return op, nil
+}
+
+// ReadMatrixMarket reads a matrix from a Matrix Market file in the coordinate or array format, with real, integer or
+// pattern values, and general or symmetric symmetry. The entries above the diagonal of a symmetric matrix are added
+// to the result, so that it contains every non-zero entry.
+func ReadMatrixMarket(r io.Reader) (SparseMatrix, MatrixMarketHeader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+
+	// Read the banner.
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return SparseMatrix{}, MatrixMarketHeader{}, err
+		}
+		return SparseMatrix{}, MatrixMarketHeader{}, errors.New("the Matrix Market file is empty")
+	}
+	line++
+	banner := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" || banner[1] != "matrix" {
+		return SparseMatrix{}, MatrixMarketHeader{}, fmt.Errorf("line 1: expected a banner such as '%%%%MatrixMarket matrix coordinate real general', but found %q", scanner.Text())
+	}
+	header := MatrixMarketHeader{
+		Format:   MatrixMarketFormat(banner[2]),
+		Field:    MatrixMarketField(banner[3]),
+		Symmetry: MatrixMarketSymmetry(banner[4]),
+	}
+	if err := header.validate(); err != nil {
+		return SparseMatrix{}, header, fmt.Errorf("line 1: %v", err)
+	}
+
+	// nextFields returns the fields of the next line which isn't blank or a comment.
+	nextFields := func() ([]string, bool) {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "%") {
+				continue
+			}
+			return strings.Fields(text), true
+		}
+		return nil, false
+	}
+	parseInt := func(s string, name string) (int, error) {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("line %d: cannot read %q as the %s", line, s, name)
+		}
+		return v, nil
+	}
+	parseValue := func(s string) (float64, error) {
+		if header.Field == MatrixMarketInteger {
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: cannot read %q as an integer", line, s)
+			}
+			return float64(v), nil
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: cannot read %q as a number", line, s)
+		}
+		return v, nil
+	}
+	fail := func(err error) (SparseMatrix, MatrixMarketHeader, error) {
+		return SparseMatrix{}, header, err
+	}
+
+	// Read the size.
+	size, ok := nextFields()
+	expectedSizeFields := 3
+	if header.Format == MatrixMarketArray {
+		expectedSizeFields = 2
+	}
+	if !ok || len(size) != expectedSizeFields {
+		if err := scanner.Err(); err != nil {
+			return fail(err)
+		}
+		return fail(fmt.Errorf("line %d: expected the size line to contain %d numbers", line, expectedSizeFields))
+	}
+	m := SparseMatrix{Entries: []SparseEntry{}}
+	var err error
+	if m.Rows, err = parseInt(size[0], "number of rows"); err != nil {
+		return fail(err)
+	}
+	if m.Columns, err = parseInt(size[1], "number of columns"); err != nil {
+		return fail(err)
+	}
+	if header.Symmetry == MatrixMarketSymmetric && m.Rows != m.Columns {
+		return fail(fmt.Errorf("line %d: a symmetric matrix must be square, but the size is %dx%d", line, m.Rows, m.Columns))
+	}
+
+	add := func(row, column int, value float64) {
+		if value == 0 {
+			return
+		}
+		m.Entries = append(m.Entries, SparseEntry{Row: row, Column: column, Value: value})
+		if header.Symmetry == MatrixMarketSymmetric && row != column {
+			m.Entries = append(m.Entries, SparseEntry{Row: column, Column: row, Value: value})
+		}
+	}
+
+	if header.Format == MatrixMarketCoordinate {
+		count, err := parseInt(size[2], "number of entries")
+		if err != nil {
+			return fail(err)
+		}
+		expectedFields := 3
+		if header.Field == MatrixMarketPattern {
+			expectedFields = 2
+		}
+		for i := 0; i < count; i++ {
+			fields, ok := nextFields()
+			if !ok {
+				if err := scanner.Err(); err != nil {
+					return fail(err)
+				}
+				return fail(fmt.Errorf("line %d: expected %d entries, but found %d", line, count, i))
+			}
+			if len(fields) != expectedFields {
+				return fail(fmt.Errorf("line %d: expected %d fields, but found %d", line, expectedFields, len(fields)))
+			}
+			row, err := parseInt(fields[0], "row")
+			if err != nil {
+				return fail(err)
+			}
+			column, err := parseInt(fields[1], "column")
+			if err != nil {
+				return fail(err)
+			}
+			if row < 1 || row > m.Rows || column < 1 || column > m.Columns {
+				return fail(fmt.Errorf("line %d: the entry (%d, %d) is outside of the %dx%d matrix", line, row, column, m.Rows, m.Columns))
+			}
+			if header.Symmetry == MatrixMarketSymmetric && column > row {
+				return fail(fmt.Errorf("line %d: the entry (%d, %d) of a symmetric matrix is above the diagonal", line, row, column))
+			}
+			value := 1.0
+			if header.Field != MatrixMarketPattern {
+				if value, err = parseValue(fields[2]); err != nil {
+					return fail(err)
+				}
+			}
+			add(row-1, column-1, value)
+		}
+	} else {
+		// Values are listed in column-major order. Symmetric matrices only list the entries on and below the diagonal.
+		for column := 0; column < m.Columns; column++ {
+			firstRow := 0
+			if header.Symmetry == MatrixMarketSymmetric {
+				firstRow = column
+			}
+			for row := firstRow; row < m.Rows; row++ {
+				fields, ok := nextFields()
+				if !ok {
+					if err := scanner.Err(); err != nil {
+						return fail(err)
+					}
+					return fail(fmt.Errorf("line %d: expected a value for the entry (%d, %d)", line, row+1, column+1))
+				}
+				if len(fields) != 1 {
+					return fail(fmt.Errorf("line %d: expected 1 field, but found %d", line, len(fields)))
+				}
+				value, err := parseValue(fields[0])
+				if err != nil {
+					return fail(err)
+				}
+				add(row, column, value)
+			}
+		}
+	}
+
+	if fields, ok := nextFields(); ok {
+		return fail(fmt.Errorf("line %d: unexpected data after the last entry: %q", line, strings.Join(fields, " ")))
+	}
+	if err := scanner.Err(); err != nil {
+		return fail(err)
+	}
+	return m, header, nil
+}
+
+// WriteMatrixMarket writes the matrix to a Matrix Market file using the format, field and symmetry of the header.
+// Integer files can only contain whole numbers, pattern files only record the positions of the non-zero entries, and
+// symmetric files can only contain symmetric matrices.
+func WriteMatrixMarket(w io.Writer, m SparseMatrix, header MatrixMarketHeader) error {
+	if err := header.validate(); err != nil {
+		return err
+	}
+	for _, e := range m.Entries {
+		if e.Row < 0 || e.Row >= m.Rows || e.Column < 0 || e.Column >= m.Columns {
+			return fmt.Errorf("the entry (%d, %d) is outside of the %dx%d matrix", e.Row, e.Column, m.Rows, m.Columns)
+		}
+	}
+	entries := m.merged()
+	if header.Symmetry == MatrixMarketSymmetric {
+		if m.Rows != m.Columns {
+			return fmt.Errorf("a symmetric matrix must be square, but the size is %dx%d", m.Rows, m.Columns)
+		}
+		values := make(map[[2]int]float64, len(entries))
+		for _, e := range entries {
+			values[[2]int{e.Row, e.Column}] = e.Value
+		}
+		for _, e := range entries {
+			if e.Row != e.Column && values[[2]int{e.Column, e.Row}] != e.Value {
+				return fmt.Errorf("the matrix is not symmetric, the entries (%d, %d) and (%d, %d) are different", e.Row, e.Column, e.Column, e.Row)
+			}
+		}
+	}
+	formatValue := func(v float64) (string, error) {
+		if header.Field == MatrixMarketInteger {
+			if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+				return "", fmt.Errorf("the value %v cannot be written to an integer Matrix Market file", v)
+			}
+			return strconv.FormatFloat(v, 'f', 0, 64), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	}
+	// included returns whether the entry is written, i.e. it's on or below the diagonal of a symmetric matrix.
+	included := func(row, column int) bool {
+		return header.Symmetry != MatrixMarketSymmetric || column <= row
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, header.String())
+	if header.Format == MatrixMarketArray {
+		fmt.Fprintf(bw, "%d %d\n", m.Rows, m.Columns)
+		// The array format contains every entry, so only it needs the dense matrix.
+		dense := m.Dense()
+		for j := 0; j < m.Columns; j++ {
+			for i := 0; i < m.Rows; i++ {
+				if !included(i, j) {
+					continue
+				}
+				value, err := formatValue(dense[i][j])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(bw, value)
+			}
+		}
+		return bw.Flush()
+	}
+
+	// Write the non-zero entries in column-major order.
+	var written []SparseEntry
+	for _, e := range entries {
+		if included(e.Row, e.Column) {
+			written = append(written, e)
+		}
+	}
+	fmt.Fprintf(bw, "%d %d %d\n", m.Rows, m.Columns, len(written))
+	for _, e := range written {
+		if header.Field == MatrixMarketPattern {
+			fmt.Fprintf(bw, "%d %d\n", e.Row+1, e.Column+1)
+			continue
+		}
+		value, err := formatValue(e.Value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "%d %d %s\n", e.Row+1, e.Column+1, value)
+	}
+	return bw.Flush()
+}
+
+// merged returns the non-zero entries in column-major order, with the values of entries at the same position added
+// together.
+func (m SparseMatrix) merged() []SparseEntry {
+	sums := make(map[[2]int]float64, len(m.Entries))
+	for _, e := range m.Entries {
+		sums[[2]int{e.Row, e.Column}] += e.Value
+	}
+	op := make([]SparseEntry, 0, len(sums))
+	for position, value := range sums {
+		if value != 0 {
+			op = append(op, SparseEntry{Row: position[0], Column: position[1], Value: value})
+		}
+	}
+	sort.Slice(op, func(a, b int) bool {
+		if op[a].Column != op[b].Column {
+			return op[a].Column < op[b].Column
+		}
+		return op[a].Row < op[b].Row
+	})
+	return op
+}
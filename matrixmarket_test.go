@@ -0,0 +1,282 @@
+package linear
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarketFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                string
+		expected             [][]float64
+		expectedHeader       MatrixMarketHeader
+		expectedErrorMessage string
+	}{
+		{
+			name: "coordinate real general",
+			input: `%%MatrixMarket matrix coordinate real general
+% a comment
+2 3 3
+1 1 1.5
+2 3 -2
+1 2 1e-3
+`,
+			expected:       [][]float64{{1.5, 0.001, 0}, {0, 0, -2}},
+			expectedHeader: MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketGeneral},
+		},
+		{
+			name:           "coordinate integer symmetric",
+			input:          "%%MatrixMarket matrix coordinate integer symmetric\n2 2 2\n1 1 4\n2 1 -1\n",
+			expected:       [][]float64{{4, -1}, {-1, 0}},
+			expectedHeader: MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketInteger, Symmetry: MatrixMarketSymmetric},
+		},
+		{
+			name:           "coordinate pattern",
+			input:          "%%MatrixMarket matrix coordinate pattern general\n2 2 2\n1 2\n2 1\n",
+			expected:       [][]float64{{0, 1}, {1, 0}},
+			expectedHeader: MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketPattern, Symmetry: MatrixMarketGeneral},
+		},
+		{
+			name:           "array real general",
+			input:          "%%MatrixMarket matrix array real general\n2 2\n1\n3\n2\n4\n",
+			expected:       [][]float64{{1, 2}, {3, 4}},
+			expectedHeader: MatrixMarketHeader{Format: MatrixMarketArray, Field: MatrixMarketReal, Symmetry: MatrixMarketGeneral},
+		},
+		{
+			name:           "array real symmetric",
+			input:          "%%MatrixMarket matrix array real symmetric\n2 2\n1\n2\n3\n",
+			expected:       [][]float64{{1, 2}, {2, 3}},
+			expectedHeader: MatrixMarketHeader{Format: MatrixMarketArray, Field: MatrixMarketReal, Symmetry: MatrixMarketSymmetric},
+		},
+		{
+			name:                 "empty file",
+			input:                "",
+			expectedErrorMessage: "the Matrix Market file is empty",
+		},
+		{
+			name:                 "missing banner",
+			input:                "2 2 1\n1 1 1\n",
+			expectedErrorMessage: "line 1: expected a banner such as '%%MatrixMarket matrix coordinate real general', but found \"2 2 1\"",
+		},
+		{
+			name:                 "complex values",
+			input:                "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+			expectedErrorMessage: "line 1: the Matrix Market field \"complex\" is not supported",
+		},
+		{
+			name:                 "array pattern",
+			input:                "%%MatrixMarket matrix array pattern general\n1 1\n",
+			expectedErrorMessage: "line 1: the Matrix Market pattern field can only be used with the coordinate format",
+		},
+		{
+			name:                 "entry outside the matrix",
+			input:                "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+			expectedErrorMessage: "line 3: the entry (3, 1) is outside of the 2x2 matrix",
+		},
+		{
+			name:                 "symmetric entry above the diagonal",
+			input:                "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n1 2 1\n",
+			expectedErrorMessage: "line 3: the entry (1, 2) of a symmetric matrix is above the diagonal",
+		},
+		{
+			name:                 "invalid integer",
+			input:                "%%MatrixMarket matrix coordinate integer general\n1 1 1\n1 1 1.5\n",
+			expectedErrorMessage: "line 3: cannot read \"1.5\" as an integer",
+		},
+		{
+			name:                 "missing entries",
+			input:                "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+			expectedErrorMessage: "line 3: expected 2 entries, but found 1",
+		},
+		{
+			name:                 "extra entries",
+			input:                "%%MatrixMarket matrix array real general\n1 1\n1\n2\n",
+			expectedErrorMessage: "line 4: unexpected data after the last entry: \"2\"",
+		},
+	}
+
+	for _, test := range tests {
+		actual, header, err := ReadMatrixMarket(strings.NewReader(test.input))
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if !reflect.DeepEqual(actual.Dense(), test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual.Dense())
+		}
+		if header != test.expectedHeader {
+			t.Errorf("%s: expected header %v, but got %v", test.name, test.expectedHeader, header)
+		}
+	}
+}
+
+func TestWriteMatrixMarketFunction(t *testing.T) {
+	symmetric := SparseMatrix{Rows: 2, Columns: 2, Entries: []SparseEntry{
+		{Row: 0, Column: 0, Value: 4},
+		{Row: 1, Column: 0, Value: -1},
+		{Row: 0, Column: 1, Value: -1},
+	}}
+
+	tests := []struct {
+		name                 string
+		input                SparseMatrix
+		header               MatrixMarketHeader
+		expected             string
+		expectedErrorMessage string
+	}{
+		{
+			name:     "coordinate real general",
+			input:    symmetric,
+			header:   MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketGeneral},
+			expected: "%%MatrixMarket matrix coordinate real general\n2 2 3\n1 1 4\n2 1 -1\n1 2 -1\n",
+		},
+		{
+			name:     "coordinate integer symmetric",
+			input:    symmetric,
+			header:   MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketInteger, Symmetry: MatrixMarketSymmetric},
+			expected: "%%MatrixMarket matrix coordinate integer symmetric\n2 2 2\n1 1 4\n2 1 -1\n",
+		},
+		{
+			name:     "coordinate pattern",
+			input:    symmetric,
+			header:   MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketPattern, Symmetry: MatrixMarketGeneral},
+			expected: "%%MatrixMarket matrix coordinate pattern general\n2 2 3\n1 1\n2 1\n1 2\n",
+		},
+		{
+			name:     "array real symmetric",
+			input:    symmetric,
+			header:   MatrixMarketHeader{Format: MatrixMarketArray, Field: MatrixMarketReal, Symmetry: MatrixMarketSymmetric},
+			expected: "%%MatrixMarket matrix array real symmetric\n2 2\n4\n-1\n0\n",
+		},
+		{
+			name:                 "integer field with a fraction",
+			input:                NewSparseMatrixFromVector(NewVector(1.5)),
+			header:               MatrixMarketHeader{Format: MatrixMarketArray, Field: MatrixMarketInteger, Symmetry: MatrixMarketGeneral},
+			expectedErrorMessage: "the value 1.5 cannot be written to an integer Matrix Market file",
+		},
+		{
+			name:                 "symmetric field with a matrix which isn't symmetric",
+			input:                SparseMatrix{Rows: 2, Columns: 2, Entries: []SparseEntry{{Row: 1, Column: 0, Value: 1}}},
+			header:               MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketSymmetric},
+			expectedErrorMessage: "the matrix is not symmetric, the entries (1, 0) and (0, 1) are different",
+		},
+		{
+			name:                 "entry outside the matrix",
+			input:                SparseMatrix{Rows: 1, Columns: 1, Entries: []SparseEntry{{Row: 1, Column: 0, Value: 1}}},
+			header:               MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketGeneral},
+			expectedErrorMessage: "the entry (1, 0) is outside of the 1x1 matrix",
+		},
+	}
+
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		err := WriteMatrixMarket(buf, test.input, test.header)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected %q, but got %q", test.name, test.expected, buf.String())
+		}
+
+		// Reading the output gives back the same matrix, apart from the values of a pattern.
+		actual, _, err := ReadMatrixMarket(buf)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading the output: %v", test.name, err)
+			continue
+		}
+		if test.header.Field != MatrixMarketPattern && !reflect.DeepEqual(actual.Dense(), test.input.Dense()) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.input.Dense(), actual.Dense())
+		}
+	}
+}
+
+func TestWriteMatrixMarketDoesNotAllocateTheDenseMatrix(t *testing.T) {
+	// A dense 200000x200000 matrix would need about 320 GB.
+	m := SparseMatrix{Rows: 200000, Columns: 200000, Entries: []SparseEntry{
+		{Row: 199999, Column: 0, Value: 2},
+		{Row: 0, Column: 199999, Value: 2},
+		{Row: 5, Column: 5, Value: 1},
+		{Row: 5, Column: 5, Value: 0.5},
+		{Row: 7, Column: 7, Value: 1},
+		{Row: 7, Column: 7, Value: -1},
+	}}
+
+	tests := []struct {
+		name     string
+		header   MatrixMarketHeader
+		expected string
+	}{
+		{
+			name:     "general",
+			header:   MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketGeneral},
+			expected: "%%MatrixMarket matrix coordinate real general\n200000 200000 3\n200000 1 2\n6 6 1.5\n1 200000 2\n",
+		},
+		{
+			name:     "symmetric",
+			header:   MatrixMarketHeader{Format: MatrixMarketCoordinate, Field: MatrixMarketReal, Symmetry: MatrixMarketSymmetric},
+			expected: "%%MatrixMarket matrix coordinate real symmetric\n200000 200000 2\n200000 1 2\n6 6 1.5\n",
+		},
+	}
+
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		if err := WriteMatrixMarket(buf, m, test.header); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected %q, but got %q", test.name, test.expected, buf.String())
+		}
+	}
+}
+
+func TestSparseMatrixSystemConversion(t *testing.T) {
+	s := NewSystem(
+		NewEquation(NewVector(1, 0, 2), 3),
+		NewEquation(NewVector(0, -1, 0), 4),
+	)
+	m, err := NewSparseMatrixFromSystem(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.Entries) != 3 || m.Rows != 2 || m.Columns != 3 {
+		t.Errorf("expected a 2x3 matrix with 3 entries, but got %+v", m)
+	}
+	actual, err := m.System(NewVector(3, 4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eq, _ := actual.Eq(s); !eq {
+		t.Errorf("expected %v, but got %v", s, actual)
+	}
+	if _, err := m.System(NewVector(1)); err == nil || err.Error() != "the matrix has 2 rows, but there are 1 constant terms" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	v, err := NewSparseMatrixFromVector(NewVector(0, 2, -1)).Vector()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(v, NewVector(0, 2, -1)) {
+		t.Errorf("expected [0 2 -1], but got %v", v)
+	}
+	if _, err := m.Vector(); err == nil || err.Error() != "only a matrix with 1 column can be converted to a vector, but the matrix has 3 columns" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
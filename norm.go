@@ -0,0 +1,146 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// L1Norm calculates the taxicab (Manhattan) norm of the vector, which is the sum of the absolute value of each element.
+func (v1 Vector) L1Norm() float64 {
+	abs := make([]float64, len(v1))
+	for i, v := range v1 {
+		abs[i] = math.Abs(v)
+	}
+	return neumaierSum(abs, nil)
+}
+
+// L2Norm calculates the Euclidean norm of the vector. It is the same as the Magnitude.
+func (v1 Vector) L2Norm() float64 {
+	return v1.Magnitude()
+}
+
+// InfinityNorm calculates the maximum (Chebyshev) norm of the vector, which is the largest absolute value of any
+// element.
+func (v1 Vector) InfinityNorm() float64 {
+	var max float64
+	for _, v := range v1 {
+		if a := math.Abs(v); a > max {
+			max = a
+		}
+	}
+	return max
+}
+
+// PNorm calculates the p-norm of the vector, i.e. the pth root of the sum of the absolute value of each element raised
+// to the power p. p must be at least 1, a p of +Inf returns the InfinityNorm.
+func (v1 Vector) PNorm(p float64) (float64, error) {
+	if math.IsNaN(p) || p < 1 {
+		return 0, fmt.Errorf("cannot calculate the p-norm of the vector because p must be greater than or equal to 1, but was %v", p)
+	}
+	switch {
+	case p == 1:
+		return v1.L1Norm(), nil
+	case p == 2:
+		return v1.L2Norm(), nil
+	case math.IsInf(p, 1):
+		return v1.InfinityNorm(), nil
+	}
+	var sum float64
+	for _, v := range v1 {
+		sum += math.Pow(math.Abs(v), p)
+	}
+	return math.Pow(sum, 1/p), nil
+}
+
+// WeightedNorm calculates the weighted Euclidean norm of the vector, i.e. the square root of the sum of each element
+// squared, multiplied by its weight. The weights must have the same dimensions as the vector and must not be negative.
+func (v1 Vector) WeightedNorm(weights Vector) (float64, error) {
+	if len(v1) != len(weights) {
+		return 0, fmt.Errorf("cannot calculate the weighted norm of the vector because the vector and weights have different dimensions (%d and %d)", len(v1), len(weights))
+	}
+	var sum float64
+	for i, v := range v1 {
+		if weights[i] < 0 {
+			return 0, fmt.Errorf("cannot calculate the weighted norm of the vector because the weight at index %d is negative (%v)", i, weights[i])
+		}
+		sum += weights[i] * v * v
+	}
+	return math.Sqrt(sum), nil
+}
+
+// EuclideanDistance calculates the straight line distance between the current vector and v2.
+func (v1 Vector) EuclideanDistance(v2 Vector) (float64, error) {
+	if len(v1) != len(v2) {
+		return 0, fmt.Errorf("cannot calculate the euclidean distance between the vectors because they have different dimensions (%d and %d)", len(v1), len(v2))
+	}
+	d, _ := v1.Sub(v2)
+	return d.L2Norm(), nil
+}
+
+// ManhattanDistance calculates the sum of the absolute differences between each element of the current vector and v2.
+func (v1 Vector) ManhattanDistance(v2 Vector) (float64, error) {
+	if len(v1) != len(v2) {
+		return 0, fmt.Errorf("cannot calculate the manhattan distance between the vectors because they have different dimensions (%d and %d)", len(v1), len(v2))
+	}
+	d, _ := v1.Sub(v2)
+	return d.L1Norm(), nil
+}
+
+// ChebyshevDistance calculates the largest absolute difference between any element of the current vector and v2.
+func (v1 Vector) ChebyshevDistance(v2 Vector) (float64, error) {
+	if len(v1) != len(v2) {
+		return 0, fmt.Errorf("cannot calculate the chebyshev distance between the vectors because they have different dimensions (%d and %d)", len(v1), len(v2))
+	}
+	d, _ := v1.Sub(v2)
+	return d.InfinityNorm(), nil
+}
+
+// CosineDistance calculates 1 minus the cosine of the angle between the current vector and v2. Vectors which point in
+// the same direction have a distance of 0, orthogonal vectors have a distance of 1 and opposite vectors have a
+// distance of 2. The distance is undefined if either vector is the zero vector.
+func (v1 Vector) CosineDistance(v2 Vector) (float64, error) {
+	dp, err := v1.DotProduct(v2)
+	if err != nil {
+		return 0, err
+	}
+	if v1.IsZeroVector() || v2.IsZeroVector() {
+		return 0, errors.New("cannot calculate the cosine distance because the angle to a zero vector is undefined")
+	}
+	cosine := dp / (v1.Magnitude() * v2.Magnitude())
+	// Clamp rounding errors so that the result stays within [0, 2].
+	cosine = math.Max(-1, math.Min(1, cosine))
+	return 1 - cosine, nil
+}
+
+// MahalanobisDistance calculates the distance between the current vector and v2, scaled by the inverse of the
+// covariance matrix. Each equation in the covariance system is a row of the covariance matrix and the constant
+// terms are ignored. The covariance matrix must be square, have the same dimensions as the vectors, and be
+// invertible and positive definite.
+func (v1 Vector) MahalanobisDistance(v2 Vector, covariance System) (float64, error) {
+	if len(v1) != len(v2) {
+		return 0, fmt.Errorf("cannot calculate the mahalanobis distance between the vectors because they have different dimensions (%d and %d)", len(v1), len(v2))
+	}
+	if len(covariance) != len(v1) || !covariance.AllEquationsHaveSameNumberOfTerms() || (len(covariance) > 0 && len(covariance[0].NormalVector) != len(v1)) {
+		return 0, fmt.Errorf("cannot calculate the mahalanobis distance because the covariance matrix must be %dx%d", len(v1), len(v1))
+	}
+	d, _ := v1.Sub(v2)
+
+	// Rather than inverting the covariance matrix, solve Σy = d to find y = Σ⁻¹d.
+	s := make(System, len(covariance))
+	for i, e := range covariance {
+		s[i] = NewEquation(e.NormalVector, d[i])
+	}
+	y, noSolution, infiniteSolutions, err := s.Solve()
+	if err != nil {
+		return 0, err
+	}
+	if noSolution || infiniteSolutions {
+		return 0, errors.New("cannot calculate the mahalanobis distance because the covariance matrix is singular")
+	}
+	dp, _ := d.DotProduct(y)
+	if dp < 0 {
+		return 0, errors.New("cannot calculate the mahalanobis distance because the covariance matrix is not positive definite")
+	}
+	return math.Sqrt(dp), nil
+}
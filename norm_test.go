@@ -0,0 +1,273 @@
+package linear
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+func TestVectorNormFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Vector
+		l1       float64
+		l2       float64
+		infinity float64
+		p3       float64
+		weights  Vector
+		weighted float64
+	}{
+		{
+			name:     "Zeroes",
+			input:    NewVector(0, 0, 0),
+			l1:       0,
+			l2:       0,
+			infinity: 0,
+			p3:       0,
+			weights:  NewVector(1, 2, 3),
+			weighted: 0,
+		},
+		{
+			name:     "Pythagoran triangle",
+			input:    NewVector(3, -4),
+			l1:       7,
+			l2:       5,
+			infinity: 4,
+			p3:       math.Cbrt(27 + 64),
+			weights:  NewVector(1, 1),
+			weighted: 5,
+		},
+		{
+			name:     "Weighted",
+			input:    NewVector(1, -2, 2),
+			l1:       5,
+			l2:       3,
+			infinity: 2,
+			p3:       math.Cbrt(1 + 8 + 8),
+			weights:  NewVector(4, 0, 3),
+			weighted: 4,
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.input.L1Norm(); actual != test.l1 {
+			t.Errorf("%s: For the L1 norm of %v, expected %v, but got %v", test.name, test.input, test.l1, actual)
+		}
+		if actual := test.input.L2Norm(); actual != test.l2 {
+			t.Errorf("%s: For the L2 norm of %v, expected %v, but got %v", test.name, test.input, test.l2, actual)
+		}
+		if actual := test.input.InfinityNorm(); actual != test.infinity {
+			t.Errorf("%s: For the infinity norm of %v, expected %v, but got %v", test.name, test.input, test.infinity, actual)
+		}
+		for _, p := range []struct {
+			p        float64
+			expected float64
+		}{{1, test.l1}, {2, test.l2}, {3, test.p3}, {math.Inf(1), test.infinity}} {
+			actual, err := test.input.PNorm(p.p)
+			if err != nil {
+				t.Errorf("%s: For the %v-norm of %v, unexpected error: %v", test.name, p.p, test.input, err)
+			}
+			if !tolerance.IsWithin(actual, p.expected, DefaultTolerance) {
+				t.Errorf("%s: For the %v-norm of %v, expected %v, but got %v", test.name, p.p, test.input, p.expected, actual)
+			}
+		}
+		actual, err := test.input.WeightedNorm(test.weights)
+		if err != nil {
+			t.Errorf("%s: For the weighted norm of %v, unexpected error: %v", test.name, test.input, err)
+		}
+		if actual != test.weighted {
+			t.Errorf("%s: For the weighted norm of %v with weights %v, expected %v, but got %v", test.name, test.input, test.weights, test.weighted, actual)
+		}
+	}
+}
+
+func TestVectorNormErrors(t *testing.T) {
+	if _, err := NewVector(1, 2).PNorm(0.5); err == nil {
+		t.Errorf("expected an error for a p-norm with p less than 1")
+	}
+	if _, err := NewVector(1, 2).PNorm(math.NaN()); err == nil {
+		t.Errorf("expected an error for a p-norm with p of NaN")
+	}
+	expected := "cannot calculate the weighted norm of the vector because the vector and weights have different dimensions (2 and 1)"
+	if _, err := NewVector(1, 2).WeightedNorm(NewVector(1)); err == nil || err.Error() != expected {
+		t.Errorf("expected error '%v', but got '%v'", expected, err)
+	}
+	expected = "cannot calculate the weighted norm of the vector because the weight at index 1 is negative (-1)"
+	if _, err := NewVector(1, 2).WeightedNorm(NewVector(1, -1)); err == nil || err.Error() != expected {
+		t.Errorf("expected error '%v', but got '%v'", expected, err)
+	}
+}
+
+func TestVectorDistanceFunctions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		a                    Vector
+		b                    Vector
+		euclidean            float64
+		manhattan            float64
+		chebyshev            float64
+		cosine               float64
+		expectedErrorMessage string
+	}{
+		{
+			name:      "Same vector",
+			a:         NewVector(1, 2, 3),
+			b:         NewVector(1, 2, 3),
+			euclidean: 0,
+			manhattan: 0,
+			chebyshev: 0,
+			cosine:    0,
+		},
+		{
+			name:      "Orthogonal",
+			a:         NewVector(3, 0),
+			b:         NewVector(0, 4),
+			euclidean: 5,
+			manhattan: 7,
+			chebyshev: 4,
+			cosine:    1,
+		},
+		{
+			name:      "Opposite",
+			a:         NewVector(1, 1),
+			b:         NewVector(-2, -2),
+			euclidean: math.Sqrt(18),
+			manhattan: 6,
+			chebyshev: 3,
+			cosine:    2,
+		},
+		{
+			name:                 "Mismatched dimensions",
+			a:                    NewVector(1),
+			b:                    NewVector(1, 2),
+			expectedErrorMessage: "because they have different dimensions (1 and 2)",
+		},
+	}
+
+	for _, test := range tests {
+		functions := []struct {
+			name     string
+			f        func(Vector) (float64, error)
+			expected float64
+		}{
+			{"euclidean", test.a.EuclideanDistance, test.euclidean},
+			{"manhattan", test.a.ManhattanDistance, test.manhattan},
+			{"chebyshev", test.a.ChebyshevDistance, test.chebyshev},
+			{"cosine", test.a.CosineDistance, test.cosine},
+		}
+		for _, f := range functions {
+			actual, err := f.f(test.b)
+			if err != nil {
+				if test.expectedErrorMessage == "" {
+					t.Errorf("%s: For the %s distance between %v and %v, no error was expected, but got '%v'", test.name, f.name, test.a, test.b, err)
+					continue
+				}
+				if !strings.HasSuffix(err.Error(), test.expectedErrorMessage) {
+					t.Errorf("%s: For the %s distance between %v and %v, expected error message ending '%v', but got '%v'", test.name, f.name, test.a, test.b, test.expectedErrorMessage, err)
+				}
+				continue
+			}
+			if !tolerance.IsWithin(actual, f.expected, DefaultTolerance) {
+				t.Errorf("%s: For the %s distance between %v and %v, expected %v, but got %v", test.name, f.name, test.a, test.b, f.expected, actual)
+			}
+		}
+	}
+}
+
+func TestCosineDistanceOfZeroVector(t *testing.T) {
+	_, err := NewVector(0, 0).CosineDistance(NewVector(1, 1))
+	if err == nil {
+		t.Errorf("expected an error calculating the cosine distance to a zero vector")
+	}
+}
+
+func TestMahalanobisDistanceFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		a                    Vector
+		b                    Vector
+		covariance           System
+		expected             float64
+		expectedErrorMessage string
+	}{
+		{
+			name: "identity covariance is the euclidean distance",
+			a:    NewVector(3, 0),
+			b:    NewVector(0, 4),
+			covariance: NewSystem(
+				NewEquation(NewVector(1, 0), 0),
+				NewEquation(NewVector(0, 1), 0)),
+			expected: 5,
+		},
+		{
+			name: "diagonal covariance scales each dimension by its variance",
+			a:    NewVector(2, 3),
+			b:    NewVector(0, 0),
+			covariance: NewSystem(
+				NewEquation(NewVector(4, 0), 0),
+				NewEquation(NewVector(0, 9), 0)),
+			expected: math.Sqrt(2),
+		},
+		{
+			name: "correlated covariance",
+			a:    NewVector(1, 1),
+			b:    NewVector(0, 0),
+			covariance: NewSystem(
+				NewEquation(NewVector(2, 1), 0),
+				NewEquation(NewVector(1, 2), 0)),
+			// Σ⁻¹ = 1/3 [[2, -1], [-1, 2]], so dᵀΣ⁻¹d = 1/3 (2 - 1 - 1 + 2) = 2/3
+			expected: math.Sqrt(2.0 / 3.0),
+		},
+		{
+			name: "singular covariance",
+			a:    NewVector(1, 1),
+			b:    NewVector(0, 0),
+			covariance: NewSystem(
+				NewEquation(NewVector(1, 1), 0),
+				NewEquation(NewVector(1, 1), 0)),
+			expectedErrorMessage: "cannot calculate the mahalanobis distance because the covariance matrix is singular",
+		},
+		{
+			name: "covariance which is not positive definite",
+			a:    NewVector(0, 1),
+			b:    NewVector(0, 0),
+			covariance: NewSystem(
+				NewEquation(NewVector(1, 0), 0),
+				NewEquation(NewVector(0, -1), 0)),
+			expectedErrorMessage: "cannot calculate the mahalanobis distance because the covariance matrix is not positive definite",
+		},
+		{
+			name: "covariance has the wrong dimensions",
+			a:    NewVector(1, 1),
+			b:    NewVector(0, 0),
+			covariance: NewSystem(
+				NewEquation(NewVector(1, 0, 0), 0),
+				NewEquation(NewVector(0, 1, 0), 0)),
+			expectedErrorMessage: "cannot calculate the mahalanobis distance because the covariance matrix must be 2x2",
+		},
+		{
+			name:                 "Mismatched dimensions",
+			a:                    NewVector(1),
+			b:                    NewVector(1, 2),
+			expectedErrorMessage: "cannot calculate the mahalanobis distance between the vectors because they have different dimensions (1 and 2)",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.a.MahalanobisDistance(test.b, test.covariance)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error message '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+		}
+		if !tolerance.IsWithin(actual, test.expected, DefaultTolerance) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
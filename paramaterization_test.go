@@ -1,6 +1,11 @@
 package linear
 
-import "testing"
+import (
+	"math"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
 
 func TestParameterizationStringRepresentation(t *testing.T) {
 	tests := []struct {
@@ -24,6 +29,16 @@ func TestParameterizationStringRepresentation(t *testing.T) {
 			},
 			expected: "{ x₁ = -10.647 - 1.882t + 10.016s, x₂ = t, x₃ = s }",
 		},
+		{
+			input: Parameterization{
+				Basepoint: NewVector(0, 0, 0),
+				DirectionVectors: []Vector{
+					NewVector(-2, 1, 0),
+					NewVector(0, 1, 0),
+				},
+			},
+			expected: "{ x₁ = -2t, x₂ = t + s, x₃ = 0 }",
+		},
 	}
 
 	for _, test := range tests {
@@ -72,3 +87,421 @@ func TestParameterizationGetFreeVariableNameFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestParameterizationAtFunction(t *testing.T) {
+	p := Parameterization{
+		Basepoint:        NewVector(1, 2, 3),
+		DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 1)},
+	}
+
+	tests := []struct {
+		name                 string
+		parameters           Vector
+		expected             Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:       "basepoint",
+			parameters: NewVector(0, 0),
+			expected:   NewVector(1, 2, 3),
+		},
+		{
+			name:       "combination of directions",
+			parameters: NewVector(2, -1),
+			expected:   NewVector(3, 1, 2),
+		},
+		{
+			name:                 "wrong number of parameters",
+			parameters:           NewVector(1),
+			expectedErrorMessage: "the parameterization has 2 direction vectors, but 1 parameters were provided",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := p.At(test.parameters)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+
+	point := Parameterization{Basepoint: NewVector(1, 2)}
+	if actual, _ := point.At(Vector{}); !actual.Eq(NewVector(1, 2)) {
+		t.Errorf("expected a point parameterization to return the basepoint, but got %v", actual)
+	}
+}
+
+func TestParameterizationContainsAndDimensionFunctions(t *testing.T) {
+	tests := []struct {
+		name              string
+		p                 Parameterization
+		v                 Vector
+		expectedContains  bool
+		expectedDimension int
+	}{
+		{
+			name:              "point contains itself",
+			p:                 Parameterization{Basepoint: NewVector(1, 2)},
+			v:                 NewVector(1, 2),
+			expectedContains:  true,
+			expectedDimension: 0,
+		},
+		{
+			name:              "point doesn't contain another point",
+			p:                 Parameterization{Basepoint: NewVector(1, 2)},
+			v:                 NewVector(1, 3),
+			expectedContains:  false,
+			expectedDimension: 0,
+		},
+		{
+			name:              "line contains a point on it",
+			p:                 Parameterization{Basepoint: NewVector(1, 1, 1), DirectionVectors: []Vector{NewVector(1, 2, 3)}},
+			v:                 NewVector(3, 5, 7),
+			expectedContains:  true,
+			expectedDimension: 1,
+		},
+		{
+			name:              "line doesn't contain a point off it",
+			p:                 Parameterization{Basepoint: NewVector(1, 1, 1), DirectionVectors: []Vector{NewVector(1, 2, 3)}},
+			v:                 NewVector(3, 5, 6),
+			expectedContains:  false,
+			expectedDimension: 1,
+		},
+		{
+			name: "plane with dependent direction vectors",
+			p: Parameterization{
+				Basepoint:        NewVector(0, 0, 1),
+				DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(1, 1, 0)},
+			},
+			v:                 NewVector(5, -3, 1),
+			expectedContains:  true,
+			expectedDimension: 2,
+		},
+	}
+
+	for _, test := range tests {
+		contains, err := test.p.Contains(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if contains != test.expectedContains {
+			t.Errorf("%s: expected contains to be %v, but got %v", test.name, test.expectedContains, contains)
+		}
+		dimension, err := test.p.Dimension()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if dimension != test.expectedDimension {
+			t.Errorf("%s: expected dimension %v, but got %v", test.name, test.expectedDimension, dimension)
+		}
+	}
+
+	invalid := Parameterization{Basepoint: NewVector(1, 2), DirectionVectors: []Vector{NewVector(1, 2, 3)}}
+	if _, err := invalid.Dimension(); err == nil || err.Error() != "the basepoint has 2 dimensions, but the direction vector at index 0 has 3 dimensions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParameterizationSystemFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Parameterization
+		expected int
+	}{
+		{
+			name:     "point",
+			p:        Parameterization{Basepoint: NewVector(1, 2, 3)},
+			expected: 3,
+		},
+		{
+			name:     "line in 3D",
+			p:        Parameterization{Basepoint: NewVector(1, 2, 3), DirectionVectors: []Vector{NewVector(1, -1, 2)}},
+			expected: 2,
+		},
+		{
+			name: "plane in 3D",
+			p: Parameterization{
+				Basepoint:        NewVector(1, 2, 3),
+				DirectionVectors: []Vector{NewVector(1, 0, 1), NewVector(0, 1, 1)},
+			},
+			expected: 1,
+		},
+		{
+			name: "whole space",
+			p: Parameterization{
+				Basepoint:        NewVector(1, 2),
+				DirectionVectors: []Vector{NewVector(1, 0), NewVector(0, 1)},
+			},
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		s, err := test.p.System()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(s) != test.expected {
+			t.Errorf("%s: expected %d equations, but got %d: %v", test.name, test.expected, len(s), s)
+		}
+		// Solving the system gives back the same affine subspace.
+		solution, noSolution, err := s.SolutionSet()
+		if err != nil || noSolution {
+			t.Errorf("%s: expected the system %v to have a solution, but got noSolution %v and error %v", test.name, s, noSolution, err)
+			continue
+		}
+		if dimension, _ := test.p.Dimension(); len(solution.DirectionVectors) != dimension {
+			t.Errorf("%s: expected the solution to have %d direction vectors, but got %v", test.name, dimension, solution)
+		}
+		if contains, _ := test.p.Contains(solution.Basepoint); !contains {
+			t.Errorf("%s: expected %v to contain %v", test.name, test.p, solution.Basepoint)
+		}
+		for _, d := range solution.DirectionVectors {
+			if inSpan, _ := VectorSet(test.p.DirectionVectors).SpanContains(d); !inSpan {
+				t.Errorf("%s: expected the direction vector %v to be in the span of %v", test.name, d, test.p.DirectionVectors)
+			}
+		}
+	}
+}
+
+func TestParameterizationIntersectFunction(t *testing.T) {
+	xyPlane := Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)}}
+	xzPlane := Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 0, 1)}}
+	zLine := Parameterization{Basepoint: NewVector(1, 2, 5), DirectionVectors: []Vector{NewVector(0, 0, 1)}}
+	raisedPlane := Parameterization{Basepoint: NewVector(0, 0, 1), DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)}}
+
+	tests := []struct {
+		name              string
+		a                 Parameterization
+		b                 Parameterization
+		expectedKind      IntersectionKind
+		expectedDimension int
+		expectedPoint     Vector
+	}{
+		{
+			name:              "two planes meet in a line",
+			a:                 xyPlane,
+			b:                 xzPlane,
+			expectedKind:      AffineSubspaceIntersection,
+			expectedDimension: 1,
+		},
+		{
+			name:              "line crosses a plane",
+			a:                 xyPlane,
+			b:                 zLine,
+			expectedKind:      PointIntersection,
+			expectedDimension: 0,
+			expectedPoint:     NewVector(1, 2, 0),
+		},
+		{
+			name:              "parallel planes",
+			a:                 xyPlane,
+			b:                 raisedPlane,
+			expectedKind:      NoIntersection,
+			expectedDimension: -1,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.a.Intersect(test.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if actual.Kind != test.expectedKind || actual.Dimension != test.expectedDimension {
+			t.Errorf("%s: expected a %v intersection with dimension %d, but got %v", test.name, test.expectedKind, test.expectedDimension, actual)
+		}
+		if point, ok := actual.Point(); ok && !point.Eq(test.expectedPoint) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expectedPoint, point)
+		}
+	}
+
+	if _, err := xyPlane.Intersect(Parameterization{Basepoint: NewVector(1, 2)}); err == nil {
+		t.Errorf("expected an error intersecting parameterizations with different dimensions")
+	}
+}
+
+func TestParameterizationDistanceToFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        Parameterization
+		b        Parameterization
+		expected float64
+	}{
+		{
+			name:     "two points",
+			a:        Parameterization{Basepoint: NewVector(0, 0)},
+			b:        Parameterization{Basepoint: NewVector(3, 4)},
+			expected: 5,
+		},
+		{
+			name:     "point and line",
+			a:        Parameterization{Basepoint: NewVector(0, 0), DirectionVectors: []Vector{NewVector(1, 1)}},
+			b:        Parameterization{Basepoint: NewVector(0, 2)},
+			expected: math.Sqrt(2),
+		},
+		{
+			name:     "skew lines",
+			a:        Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(1, 0, 0)}},
+			b:        Parameterization{Basepoint: NewVector(5, 3, 2), DirectionVectors: []Vector{NewVector(0, 2, 0)}},
+			expected: 2,
+		},
+		{
+			name:     "parallel planes",
+			a:        Parameterization{Basepoint: NewVector(0, 0, 0), DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)}},
+			b:        Parameterization{Basepoint: NewVector(7, 7, 3), DirectionVectors: []Vector{NewVector(1, 1, 0), NewVector(1, -1, 0)}},
+			expected: 3,
+		},
+		{
+			name:     "intersecting lines",
+			a:        Parameterization{Basepoint: NewVector(0, 0), DirectionVectors: []Vector{NewVector(1, 0)}},
+			b:        Parameterization{Basepoint: NewVector(4, 4), DirectionVectors: []Vector{NewVector(1, 1)}},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.a.DistanceTo(test.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !tolerance.IsWithin(actual, test.expected, DefaultTolerance) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestParameterizationCanonicalFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Parameterization
+		expected Parameterization
+	}{
+		{
+			name:     "point is unchanged",
+			input:    Parameterization{Basepoint: NewVector(1, 2, 3), DirectionVectors: []Vector{}},
+			expected: Parameterization{Basepoint: NewVector(1, 2, 3), DirectionVectors: []Vector{}},
+		},
+		{
+			name:     "line through the origin",
+			input:    Parameterization{Basepoint: NewVector(2, 4), DirectionVectors: []Vector{NewVector(-3, -6)}},
+			expected: Parameterization{Basepoint: NewVector(0, 0), DirectionVectors: []Vector{NewVector(1, 2)}},
+		},
+		{
+			name:     "line away from the origin",
+			input:    Parameterization{Basepoint: NewVector(5, 1), DirectionVectors: []Vector{NewVector(1, 1)}},
+			expected: Parameterization{Basepoint: NewVector(2, -2), DirectionVectors: []Vector{NewVector(1, 1)}},
+		},
+		{
+			name: "plane with dependent direction vectors",
+			input: Parameterization{
+				Basepoint:        NewVector(3, -1, 2),
+				DirectionVectors: []Vector{NewVector(1, 1, 0), NewVector(2, 2, 0), NewVector(1, -1, 0)},
+			},
+			expected: Parameterization{
+				Basepoint:        NewVector(0, 0, 2),
+				DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.input.Canonical()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !actual.Basepoint.Eq(test.expected.Basepoint) {
+			t.Errorf("%s: expected basepoint %v, but got %v", test.name, test.expected.Basepoint, actual.Basepoint)
+		}
+		if len(actual.DirectionVectors) != len(test.expected.DirectionVectors) {
+			t.Errorf("%s: expected direction vectors %v, but got %v", test.name, test.expected.DirectionVectors, actual.DirectionVectors)
+			continue
+		}
+		for i := range actual.DirectionVectors {
+			if !actual.DirectionVectors[i].Eq(test.expected.DirectionVectors[i]) {
+				t.Errorf("%s: expected direction vectors %v, but got %v", test.name, test.expected.DirectionVectors, actual.DirectionVectors)
+				break
+			}
+		}
+	}
+}
+
+func TestParameterizationEqFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        Parameterization
+		b        Parameterization
+		expected bool
+	}{
+		{
+			name:     "same line with different basepoints and directions",
+			a:        Parameterization{Basepoint: NewVector(1, 1, 1), DirectionVectors: []Vector{NewVector(1, 2, 3)}},
+			b:        Parameterization{Basepoint: NewVector(-1, -3, -5), DirectionVectors: []Vector{NewVector(-2, -4, -6)}},
+			expected: true,
+		},
+		{
+			name:     "parallel lines",
+			a:        Parameterization{Basepoint: NewVector(1, 1, 1), DirectionVectors: []Vector{NewVector(1, 2, 3)}},
+			b:        Parameterization{Basepoint: NewVector(1, 1, 2), DirectionVectors: []Vector{NewVector(1, 2, 3)}},
+			expected: false,
+		},
+		{
+			name: "same plane with different spanning vectors",
+			a: Parameterization{
+				Basepoint:        NewVector(0, 0, 1),
+				DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)},
+			},
+			b: Parameterization{
+				Basepoint:        NewVector(4, 5, 1),
+				DirectionVectors: []Vector{NewVector(1, 1, 0), NewVector(1, -1, 0), NewVector(2, 0, 0)},
+			},
+			expected: true,
+		},
+		{
+			name: "line inside a plane",
+			a: Parameterization{
+				Basepoint:        NewVector(0, 0, 1),
+				DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)},
+			},
+			b:        Parameterization{Basepoint: NewVector(0, 0, 1), DirectionVectors: []Vector{NewVector(1, 0, 0)}},
+			expected: false,
+		},
+		{
+			name:     "same point",
+			a:        Parameterization{Basepoint: NewVector(1, 2)},
+			b:        Parameterization{Basepoint: NewVector(1, 2), DirectionVectors: []Vector{NewVector(0, 0)}},
+			expected: true,
+		},
+		{
+			name:     "different dimensions",
+			a:        Parameterization{Basepoint: NewVector(1, 2)},
+			b:        Parameterization{Basepoint: NewVector(1, 2, 0)},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.a.Eq(test.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+		// Equality is symmetric.
+		if reversed, _ := test.b.Eq(test.a); reversed != test.expected {
+			t.Errorf("%s: expected the reverse comparison to be %v, but got %v", test.name, test.expected, reversed)
+		}
+	}
+}
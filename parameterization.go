@@ -2,6 +2,7 @@ package linear
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"math"
@@ -17,14 +18,20 @@ type Parameterization struct {
 }
 
 func (p1 Parameterization) String() string {
+	return Formatter{}.Parameterization(p1)
+}
+
+// format writes out the parameterization using the formatter, the name function to get the name of each variable, and
+// the freeVariableName function to get the name of the free variable which multiplies each direction vector.
+func (p1 Parameterization) format(f Formatter, name func(index int) string, freeVariableName func(index int) string) string {
 	buf := bytes.NewBufferString("{ ")
 
 	for variableIndex, basepointValue := range p1.Basepoint {
-		buf.WriteString(fmt.Sprintf("x%v = ", getSubscript(variableIndex+1)))
+		buf.WriteString(name(variableIndex) + " = ")
 
 		var nonzero bool
 		if !tolerance.IsWithin(basepointValue, 0, DefaultTolerance) {
-			buf.WriteString(fmt.Sprintf("%v", basepointValue))
+			buf.WriteString(f.Number(basepointValue))
 			nonzero = true
 		}
 
@@ -40,12 +47,18 @@ func (p1 Parameterization) String() string {
 				} else {
 					sign = " + "
 				}
+			} else if value < 0 {
+				sign = "-"
 			}
 			var freeVariableCoefficient string
 			if !tolerance.IsWithin(math.Abs(value), 1, DefaultTolerance) {
-				freeVariableCoefficient = fmt.Sprintf("%v", math.Abs(value))
+				freeVariableCoefficient = f.number(math.Abs(value), false)
 			}
-			buf.WriteString(fmt.Sprintf("%v%v%v", sign, freeVariableCoefficient, getFreeVariableName(directionIndex)))
+			buf.WriteString(fmt.Sprintf("%v%v%v", sign, freeVariableCoefficient, freeVariableName(directionIndex)))
+			nonzero = true
+		}
+		if !nonzero {
+			buf.WriteString(f.Number(0))
 		}
 		if variableIndex < len(p1.Basepoint)-1 {
 			buf.WriteString(", ")
@@ -57,6 +70,12 @@ func (p1 Parameterization) String() string {
 }
 
 func getFreeVariableName(index int) string {
+	return freeVariableName(index, getSubscript)
+}
+
+// freeVariableName returns the name of the free variable at the index, using the subscript function to write out the
+// subscript.
+func freeVariableName(index int, subscript func(i int) string) string {
 	var name string
 	if index%2 == 0 {
 		name = "t"
@@ -66,7 +85,252 @@ func getFreeVariableName(index int) string {
 		index = (index - 1) / 2
 	}
 	if index > 0 {
-		name += getSubscript(index)
+		name += subscript(index)
 	}
 	return name
 }
+
+// validate checks that every direction vector has the same dimensions as the basepoint.
+func (p1 Parameterization) validate() error {
+	for i, d := range p1.DirectionVectors {
+		if len(d) != len(p1.Basepoint) {
+			return fmt.Errorf("the basepoint has %d dimensions, but the direction vector at index %d has %d dimensions", len(p1.Basepoint), i, len(d))
+		}
+	}
+	return nil
+}
+
+// At calculates the point found by multiplying each direction vector by the parameter with the same index and adding
+// the results to the basepoint.
+func (p1 Parameterization) At(parameters Vector) (Vector, error) {
+	if err := p1.validate(); err != nil {
+		return Vector{}, err
+	}
+	if len(parameters) != len(p1.DirectionVectors) {
+		return Vector{}, fmt.Errorf("the parameterization has %d direction vectors, but %d parameters were provided", len(p1.DirectionVectors), len(parameters))
+	}
+	offset, err := VectorSet(p1.DirectionVectors).LinearCombination(parameters)
+	if err != nil {
+		return Vector{}, err
+	}
+	if len(offset) == 0 {
+		return append(Vector{}, p1.Basepoint...), nil
+	}
+	// No need to check the error, the dimensions are validated above.
+	point, _ := p1.Basepoint.Add(offset)
+	return point, nil
+}
+
+// Contains determines whether the point v can be reached from the basepoint by moving along the direction vectors.
+func (p1 Parameterization) Contains(v Vector) (bool, error) {
+	if err := p1.validate(); err != nil {
+		return false, err
+	}
+	if len(v) != len(p1.Basepoint) {
+		return false, fmt.Errorf("the parameterization has %d dimensions, but the point has %d dimensions", len(p1.Basepoint), len(v))
+	}
+	offset, _ := v.Sub(p1.Basepoint)
+	if len(p1.DirectionVectors) == 0 {
+		return offset.EqWithinTolerance(make(Vector, len(v)), DefaultTolerance), nil
+	}
+	return VectorSet(p1.DirectionVectors).SpanContains(offset)
+}
+
+// Dimension returns the dimension of the affine subspace, i.e. 0 for a point, 1 for a line, 2 for a plane. It is the
+// number of linearly independent direction vectors.
+func (p1 Parameterization) Dimension() (int, error) {
+	if err := p1.validate(); err != nil {
+		return 0, err
+	}
+	return VectorSet(p1.DirectionVectors).Rank()
+}
+
+// System converts the parameterization into a system of equations which are satisfied by every point in the affine
+// subspace, and no others. The normal vectors of the equations are a basis of the vectors which are orthogonal to
+// all of the direction vectors. If the direction vectors span the whole space, the system is the single equation
+// 0 = 0, which every point satisfies.
+func (p1 Parameterization) System() (System, error) {
+	if err := p1.validate(); err != nil {
+		return System{}, err
+	}
+	dimensions := len(p1.Basepoint)
+	if dimensions == 0 {
+		return System{}, errors.New("the parameterization has no dimensions")
+	}
+
+	var normals []Vector
+	if len(p1.DirectionVectors) == 0 {
+		// A single point is the intersection of a hyperplane along each axis.
+		for i := 0; i < dimensions; i++ {
+			normal := make(Vector, dimensions)
+			normal[i] = 1
+			normals = append(normals, normal)
+		}
+	} else {
+		// The normal vectors are the solutions of d·n = 0 for every direction vector d.
+		orthogonal := make(System, len(p1.DirectionVectors))
+		for i, d := range p1.DirectionVectors {
+			orthogonal[i] = NewEquation(d, 0)
+		}
+		complement, _, err := orthogonal.SolutionSet()
+		if err != nil {
+			return System{}, err
+		}
+		normals = complement.DirectionVectors
+	}
+
+	if len(normals) == 0 {
+		return NewSystem(NewEquation(make(Vector, dimensions), 0)), nil
+	}
+	op := make(System, len(normals))
+	for i, n := range normals {
+		// No need to check the error, the dimensions are validated above.
+		constantTerm, _ := n.DotProduct(p1.Basepoint)
+		op[i] = NewEquation(n, constantTerm)
+	}
+	return op, nil
+}
+
+// Intersect calculates the intersection of two affine subspaces, by converting both to systems of equations and
+// solving them together.
+func (p1 Parameterization) Intersect(p2 Parameterization) (Intersection, error) {
+	if len(p1.Basepoint) != len(p2.Basepoint) {
+		return Intersection{}, fmt.Errorf("cannot intersect parameterizations with different dimensions (%d and %d)", len(p1.Basepoint), len(p2.Basepoint))
+	}
+	s1, err := p1.System()
+	if err != nil {
+		return Intersection{}, err
+	}
+	s2, err := p2.System()
+	if err != nil {
+		return Intersection{}, err
+	}
+	return Intersect(append(s1, s2...)...)
+}
+
+// DistanceTo calculates the shortest distance between a point in the current affine subspace and a point in p2. The
+// distance is zero if the subspaces intersect.
+func (p1 Parameterization) DistanceTo(p2 Parameterization) (float64, error) {
+	if len(p1.Basepoint) != len(p2.Basepoint) {
+		return 0, fmt.Errorf("cannot calculate the distance between parameterizations with different dimensions (%d and %d)", len(p1.Basepoint), len(p2.Basepoint))
+	}
+	if err := p1.validate(); err != nil {
+		return 0, err
+	}
+	if err := p2.validate(); err != nil {
+		return 0, err
+	}
+	// The difference between any two points is the difference between the basepoints plus a vector in the span of
+	// all of the direction vectors, so the shortest distance is the part of the difference which is orthogonal to it.
+	r, _ := p2.Basepoint.Sub(p1.Basepoint)
+	directions := append(append([]Vector{}, p1.DirectionVectors...), p2.DirectionVectors...)
+	for _, u := range orthonormalBasis(directions) {
+		r, _ = r.Sub(u.Scale(dotProduct(r, u)))
+	}
+	return r.Magnitude(), nil
+}
+
+// orthonormalBasis uses the modified Gram-Schmidt process to create a set of orthogonal unit vectors which span the
+// same space as the input vectors. Vectors which are a linear combination of the previous vectors are dropped.
+func orthonormalBasis(vectors []Vector) []Vector {
+	op := []Vector{}
+	for _, v := range vectors {
+		u := append(Vector{}, v...)
+		for _, b := range op {
+			u, _ = u.Sub(b.Scale(dotProduct(u, b)))
+		}
+		if u.Magnitude() <= DefaultTolerance*math.Max(1, v.Magnitude()) {
+			continue
+		}
+		op = append(op, u.Normalize())
+	}
+	return op
+}
+
+// dotProduct calculates the dot product of vectors which are known to have the same dimensions. The callers validate
+// the parameterization first, so the dimension error from DotProduct can't happen and is ignored.
+func dotProduct(v1, v2 Vector) float64 {
+	dp, _ := v1.DotProduct(v2)
+	return dp
+}
+
+// Canonical returns a parameterization of the same affine subspace in a standard form, so that any two
+// parameterizations of the same subspace have the same canonical form. The direction vectors are replaced with the
+// non-zero rows of the reduced row echelon form of the matrix whose rows are the direction vectors, and the basepoint
+// is replaced with the point in the subspace which is closest to the origin.
+func (p1 Parameterization) Canonical() (Parameterization, error) {
+	if err := p1.validate(); err != nil {
+		return Parameterization{}, err
+	}
+
+	directions := []Vector{}
+	if len(p1.DirectionVectors) > 0 {
+		rows := make(System, len(p1.DirectionVectors))
+		for i, d := range p1.DirectionVectors {
+			rows[i] = NewEquation(d, 0)
+		}
+		rref, _, err := rows.ComputeRREF()
+		if err != nil {
+			return Parameterization{}, err
+		}
+		for _, e := range rref {
+			if !e.NormalVector.IsZeroVector() {
+				directions = append(directions, canonicalZeros(e.NormalVector))
+			}
+		}
+	}
+
+	// Remove the part of the basepoint which is in the span of the direction vectors.
+	basepoint := append(Vector{}, p1.Basepoint...)
+	for _, u := range orthonormalBasis(directions) {
+		basepoint, _ = basepoint.Sub(u.Scale(dotProduct(basepoint, u)))
+	}
+
+	return Parameterization{
+		Basepoint:        canonicalZeros(basepoint),
+		DirectionVectors: directions,
+	}, nil
+}
+
+// canonicalZeros replaces values which are within tolerance of zero with zero, so that rounding errors and negative
+// zeros don't appear in the output.
+func canonicalZeros(v Vector) Vector {
+	op := make(Vector, len(v))
+	for i, value := range v {
+		if !tolerance.IsWithin(value, 0, DefaultTolerance) {
+			op[i] = value
+		}
+	}
+	return op
+}
+
+// Eq determines whether two parameterizations describe the same affine subspace, i.e. the same set of points, even if
+// they have different basepoints and direction vectors.
+func (p1 Parameterization) Eq(p2 Parameterization) (bool, error) {
+	if len(p1.Basepoint) != len(p2.Basepoint) {
+		return false, nil
+	}
+	d1, err := p1.Dimension()
+	if err != nil {
+		return false, err
+	}
+	d2, err := p2.Dimension()
+	if err != nil {
+		return false, err
+	}
+	if d1 != d2 {
+		return false, nil
+	}
+	// Subspaces of the same dimension are equal if one contains the basepoint and the direction vectors of the other.
+	contains, err := p1.Contains(p2.Basepoint)
+	if !contains || err != nil {
+		return false, err
+	}
+	for _, d := range p2.DirectionVectors {
+		inSpan, err := VectorSet(p1.DirectionVectors).SpanContains(d)
+		if !inSpan || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+// Package linearpb contains the Protocol Buffers types generated from proto/linear.proto, and functions to convert
+// them to and from the types in the linear package.
+package linearpb
+
+import (
+	"fmt"
+
+	"github.com/a-h/linear"
+)
+
+// FromVector converts a vector to a message.
+func FromVector(v linear.Vector) *Vector {
+	return &Vector{Values: append([]float64{}, v...)}
+}
+
+// ToVector converts a message to a vector. A nil message is an empty vector.
+func ToVector(pb *Vector) linear.Vector {
+	return append(linear.Vector{}, pb.GetValues()...)
+}
+
+// FromEquation converts an equation to a message.
+func FromEquation(e linear.Equation) *Equation {
+	return &Equation{NormalVector: FromVector(e.NormalVector), ConstantTerm: e.ConstantTerm}
+}
+
+// ToEquation converts a message to an equation.
+func ToEquation(pb *Equation) linear.Equation {
+	return linear.NewEquation(ToVector(pb.GetNormalVector()), pb.GetConstantTerm())
+}
+
+// FromSystem converts a system of equations to a message.
+func FromSystem(s linear.System) *System {
+	op := &System{Equations: make([]*Equation, len(s))}
+	for i, e := range s {
+		op.Equations[i] = FromEquation(e)
+	}
+	return op
+}
+
+// ToSystem converts a message to a system of equations.
+func ToSystem(pb *System) linear.System {
+	op := make(linear.System, len(pb.GetEquations()))
+	for i, e := range pb.GetEquations() {
+		op[i] = ToEquation(e)
+	}
+	return op
+}
+
+// FromParameterization converts a parameterization to a message.
+func FromParameterization(p linear.Parameterization) *Parameterization {
+	op := &Parameterization{Basepoint: FromVector(p.Basepoint), DirectionVectors: make([]*Vector, len(p.DirectionVectors))}
+	for i, d := range p.DirectionVectors {
+		op.DirectionVectors[i] = FromVector(d)
+	}
+	return op
+}
+
+// ToParameterization converts a message to a parameterization.
+func ToParameterization(pb *Parameterization) linear.Parameterization {
+	op := linear.Parameterization{Basepoint: ToVector(pb.GetBasepoint()), DirectionVectors: make([]linear.Vector, len(pb.GetDirectionVectors()))}
+	for i, d := range pb.GetDirectionVectors() {
+		op.DirectionVectors[i] = ToVector(d)
+	}
+	return op
+}
+
+// FromIntersection converts an intersection, i.e. the solutions of the system of equations which describe the
+// hyperplanes, to a Solution message.
+func FromIntersection(i linear.Intersection) (*Solution, error) {
+	op := &Solution{Dimension: int32(i.Dimension)}
+	switch i.Kind {
+	case linear.NoIntersection:
+		op.Kind = SolutionKind_SOLUTION_KIND_NONE
+		return op, nil
+	case linear.PointIntersection:
+		op.Kind = SolutionKind_SOLUTION_KIND_POINT
+	case linear.AffineSubspaceIntersection:
+		op.Kind = SolutionKind_SOLUTION_KIND_AFFINE_SUBSPACE
+	default:
+		return nil, fmt.Errorf("cannot convert the intersection kind %v to a solution", i.Kind)
+	}
+	op.Parameterization = FromParameterization(i.Parameterization)
+	return op, nil
+}
+
+// ToIntersection converts a Solution message to an intersection.
+func ToIntersection(pb *Solution) (linear.Intersection, error) {
+	op := linear.Intersection{Dimension: int(pb.GetDimension())}
+	switch pb.GetKind() {
+	case SolutionKind_SOLUTION_KIND_NONE:
+		op.Kind = linear.NoIntersection
+		return op, nil
+	case SolutionKind_SOLUTION_KIND_POINT:
+		op.Kind = linear.PointIntersection
+	case SolutionKind_SOLUTION_KIND_AFFINE_SUBSPACE:
+		op.Kind = linear.AffineSubspaceIntersection
+	default:
+		return linear.Intersection{}, fmt.Errorf("cannot convert the solution kind %v to an intersection", pb.GetKind())
+	}
+	op.Parameterization = ToParameterization(pb.GetParameterization())
+	return op, nil
+}
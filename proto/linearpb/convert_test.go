@@ -0,0 +1,140 @@
+package linearpb
+
+import (
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/a-h/linear"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConversionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		// toMessage converts the input to a message, and fromMessage converts a message of the same type back.
+		toMessage   func(v interface{}) (proto.Message, error)
+		newMessage  func() proto.Message
+		fromMessage func(m proto.Message) (interface{}, error)
+	}{
+		{
+			name:        "vector",
+			input:       linear.NewVector(1, -2.5, 1e-7, math.Copysign(0, -1), math.Inf(1)),
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromVector(v.(linear.Vector)), nil },
+			newMessage:  func() proto.Message { return &Vector{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToVector(m.(*Vector)), nil },
+		},
+		{
+			name:        "empty vector",
+			input:       linear.Vector{},
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromVector(v.(linear.Vector)), nil },
+			newMessage:  func() proto.Message { return &Vector{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToVector(m.(*Vector)), nil },
+		},
+		{
+			name:        "equation",
+			input:       linear.NewEquation(linear.NewVector(-1.346, 0, 0.1), -8.15),
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromEquation(v.(linear.Equation)), nil },
+			newMessage:  func() proto.Message { return &Equation{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToEquation(m.(*Equation)), nil },
+		},
+		{
+			name: "system",
+			input: linear.NewSystem(
+				linear.NewEquation(linear.NewVector(5.862, 1.178, -10.366), -8.15),
+				linear.NewEquation(linear.NewVector(-2.931, -0.589, 5.183), 0),
+			),
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromSystem(v.(linear.System)), nil },
+			newMessage:  func() proto.Message { return &System{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToSystem(m.(*System)), nil },
+		},
+		{
+			name:        "empty system",
+			input:       linear.System{},
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromSystem(v.(linear.System)), nil },
+			newMessage:  func() proto.Message { return &System{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToSystem(m.(*System)), nil },
+		},
+		{
+			name: "parameterization",
+			input: linear.Parameterization{
+				Basepoint:        linear.NewVector(-10.647, 0, 0),
+				DirectionVectors: []linear.Vector{linear.NewVector(-1.882, 1, 0), linear.NewVector(10.016, 0, 1)},
+			},
+			toMessage: func(v interface{}) (proto.Message, error) {
+				return FromParameterization(v.(linear.Parameterization)), nil
+			},
+			newMessage:  func() proto.Message { return &Parameterization{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToParameterization(m.(*Parameterization)), nil },
+		},
+		{
+			name:        "no solution",
+			input:       linear.Intersection{Kind: linear.NoIntersection, Dimension: -1},
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromIntersection(v.(linear.Intersection)) },
+			newMessage:  func() proto.Message { return &Solution{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToIntersection(m.(*Solution)) },
+		},
+		{
+			name: "line solution",
+			input: linear.Intersection{
+				Kind:             linear.AffineSubspaceIntersection,
+				Dimension:        1,
+				Parameterization: linear.Parameterization{Basepoint: linear.NewVector(1, 0), DirectionVectors: []linear.Vector{linear.NewVector(-1, 1)}},
+			},
+			toMessage:   func(v interface{}) (proto.Message, error) { return FromIntersection(v.(linear.Intersection)) },
+			newMessage:  func() proto.Message { return &Solution{} },
+			fromMessage: func(m proto.Message) (interface{}, error) { return ToIntersection(m.(*Solution)) },
+		},
+	}
+
+	for _, test := range tests {
+		m, err := test.toMessage(test.input)
+		if err != nil {
+			t.Errorf("%s: unexpected conversion error: %v", test.name, err)
+			continue
+		}
+		data, err := proto.Marshal(m)
+		if err != nil {
+			t.Errorf("%s: unexpected marshal error: %v", test.name, err)
+			continue
+		}
+		read := test.newMessage()
+		if err := proto.Unmarshal(data, read); err != nil {
+			t.Errorf("%s: unexpected unmarshal error: %v", test.name, err)
+			continue
+		}
+		actual, err := test.fromMessage(read)
+		if err != nil {
+			t.Errorf("%s: unexpected conversion error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, test.input) {
+			t.Errorf("%s: expected %#v, but got %#v", test.name, test.input, actual)
+		}
+	}
+}
+
+func TestVectorValuesArePacked(t *testing.T) {
+	data, err := proto.Marshal(FromVector(linear.NewVector(1, 2)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Field 1 with wire type 2, length 16, then 1 and 2 as little endian doubles.
+	expected := "0a10000000000000f03f0000000000000040"
+	if actual := hex.EncodeToString(data); actual != expected {
+		t.Errorf("expected %s, but got %s", expected, actual)
+	}
+}
+
+func TestToIntersectionFunction(t *testing.T) {
+	_, err := ToIntersection(&Solution{})
+	if err == nil || err.Error() != "cannot convert the solution kind SOLUTION_KIND_UNSPECIFIED to an intersection" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err = FromIntersection(linear.Intersection{Kind: linear.IntersectionKind(10)})
+	if err == nil || err.Error() != "cannot convert the intersection kind unknown to a solution" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
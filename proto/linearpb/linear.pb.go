@@ -0,0 +1,433 @@
+// Protocol Buffers schema for the types in github.com/a-h/linear.
+//
+// The generated Go types are in the linearpb package, which also contains functions to convert them to and from the
+// types in the linear package. After changing this file, regenerate them from the root of the repository with:
+//
+//	protoc --go_out=. --go_opt=module=github.com/a-h/linear proto/linear.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: proto/linear.proto
+
+package linearpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SolutionKind describes the set of solutions of a system of equations.
+type SolutionKind int32
+
+const (
+	SolutionKind_SOLUTION_KIND_UNSPECIFIED SolutionKind = 0
+	// There is no solution, i.e. the system is inconsistent.
+	SolutionKind_SOLUTION_KIND_NONE SolutionKind = 1
+	// There is a single solution.
+	SolutionKind_SOLUTION_KIND_POINT SolutionKind = 2
+	// There are infinitely many solutions.
+	SolutionKind_SOLUTION_KIND_AFFINE_SUBSPACE SolutionKind = 3
+)
+
+// Enum value maps for SolutionKind.
+var (
+	SolutionKind_name = map[int32]string{
+		0: "SOLUTION_KIND_UNSPECIFIED",
+		1: "SOLUTION_KIND_NONE",
+		2: "SOLUTION_KIND_POINT",
+		3: "SOLUTION_KIND_AFFINE_SUBSPACE",
+	}
+	SolutionKind_value = map[string]int32{
+		"SOLUTION_KIND_UNSPECIFIED":     0,
+		"SOLUTION_KIND_NONE":            1,
+		"SOLUTION_KIND_POINT":           2,
+		"SOLUTION_KIND_AFFINE_SUBSPACE": 3,
+	}
+)
+
+func (x SolutionKind) Enum() *SolutionKind {
+	p := new(SolutionKind)
+	*p = x
+	return p
+}
+
+func (x SolutionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SolutionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_linear_proto_enumTypes[0].Descriptor()
+}
+
+func (SolutionKind) Type() protoreflect.EnumType {
+	return &file_proto_linear_proto_enumTypes[0]
+}
+
+func (x SolutionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SolutionKind.Descriptor instead.
+func (SolutionKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{0}
+}
+
+// Vector is a list of real numbers. The values are packed, i.e. written as a single length-delimited block of 8 byte
+// little endian IEEE 754 values, which is the default for repeated scalar fields in proto3.
+type Vector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	mi := &file_proto_linear_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_linear_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vector) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Equation is a linear equation, where the dot product of the normal vector and the variables equals the constant
+// term, e.g. 1x₁ + 2x₂ = 3.
+type Equation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NormalVector  *Vector                `protobuf:"bytes,1,opt,name=normal_vector,json=normalVector,proto3" json:"normal_vector,omitempty"`
+	ConstantTerm  float64                `protobuf:"fixed64,2,opt,name=constant_term,json=constantTerm,proto3" json:"constant_term,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Equation) Reset() {
+	*x = Equation{}
+	mi := &file_proto_linear_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Equation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Equation) ProtoMessage() {}
+
+func (x *Equation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_linear_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Equation.ProtoReflect.Descriptor instead.
+func (*Equation) Descriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Equation) GetNormalVector() *Vector {
+	if x != nil {
+		return x.NormalVector
+	}
+	return nil
+}
+
+func (x *Equation) GetConstantTerm() float64 {
+	if x != nil {
+		return x.ConstantTerm
+	}
+	return 0
+}
+
+// System is a system of linear equations, which all have the same number of variables.
+type System struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Equations     []*Equation            `protobuf:"bytes,1,rep,name=equations,proto3" json:"equations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *System) Reset() {
+	*x = System{}
+	mi := &file_proto_linear_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *System) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*System) ProtoMessage() {}
+
+func (x *System) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_linear_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use System.ProtoReflect.Descriptor instead.
+func (*System) Descriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *System) GetEquations() []*Equation {
+	if x != nil {
+		return x.Equations
+	}
+	return nil
+}
+
+// Parameterization describes a set of points as a basepoint plus any multiple of each of the direction vectors.
+type Parameterization struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Basepoint        *Vector                `protobuf:"bytes,1,opt,name=basepoint,proto3" json:"basepoint,omitempty"`
+	DirectionVectors []*Vector              `protobuf:"bytes,2,rep,name=direction_vectors,json=directionVectors,proto3" json:"direction_vectors,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Parameterization) Reset() {
+	*x = Parameterization{}
+	mi := &file_proto_linear_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameterization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameterization) ProtoMessage() {}
+
+func (x *Parameterization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_linear_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameterization.ProtoReflect.Descriptor instead.
+func (*Parameterization) Descriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Parameterization) GetBasepoint() *Vector {
+	if x != nil {
+		return x.Basepoint
+	}
+	return nil
+}
+
+func (x *Parameterization) GetDirectionVectors() []*Vector {
+	if x != nil {
+		return x.DirectionVectors
+	}
+	return nil
+}
+
+// Solution is the set of solutions of a system of equations, i.e. the intersection of its hyperplanes.
+type Solution struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  SolutionKind           `protobuf:"varint,1,opt,name=kind,proto3,enum=linear.v1.SolutionKind" json:"kind,omitempty"`
+	// dimension is 0 for a point, 1 for a line, 2 for a plane, and so on. It is -1 if there is no solution.
+	Dimension int32 `protobuf:"zigzag32,2,opt,name=dimension,proto3" json:"dimension,omitempty"`
+	// parameterization describes the solutions. For a single solution, the basepoint is the solution and there are no
+	// direction vectors. It is not set if there is no solution.
+	Parameterization *Parameterization `protobuf:"bytes,3,opt,name=parameterization,proto3" json:"parameterization,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Solution) Reset() {
+	*x = Solution{}
+	mi := &file_proto_linear_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Solution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Solution) ProtoMessage() {}
+
+func (x *Solution) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_linear_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Solution.ProtoReflect.Descriptor instead.
+func (*Solution) Descriptor() ([]byte, []int) {
+	return file_proto_linear_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Solution) GetKind() SolutionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SolutionKind_SOLUTION_KIND_UNSPECIFIED
+}
+
+func (x *Solution) GetDimension() int32 {
+	if x != nil {
+		return x.Dimension
+	}
+	return 0
+}
+
+func (x *Solution) GetParameterization() *Parameterization {
+	if x != nil {
+		return x.Parameterization
+	}
+	return nil
+}
+
+var File_proto_linear_proto protoreflect.FileDescriptor
+
+const file_proto_linear_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/linear.proto\x12\tlinear.v1\"$\n" +
+	"\x06Vector\x12\x1a\n" +
+	"\x06values\x18\x01 \x03(\x01B\x02\x10\x01R\x06values\"g\n" +
+	"\bEquation\x126\n" +
+	"\rnormal_vector\x18\x01 \x01(\v2\x11.linear.v1.VectorR\fnormalVector\x12#\n" +
+	"\rconstant_term\x18\x02 \x01(\x01R\fconstantTerm\";\n" +
+	"\x06System\x121\n" +
+	"\tequations\x18\x01 \x03(\v2\x13.linear.v1.EquationR\tequations\"\x83\x01\n" +
+	"\x10Parameterization\x12/\n" +
+	"\tbasepoint\x18\x01 \x01(\v2\x11.linear.v1.VectorR\tbasepoint\x12>\n" +
+	"\x11direction_vectors\x18\x02 \x03(\v2\x11.linear.v1.VectorR\x10directionVectors\"\x9e\x01\n" +
+	"\bSolution\x12+\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x17.linear.v1.SolutionKindR\x04kind\x12\x1c\n" +
+	"\tdimension\x18\x02 \x01(\x11R\tdimension\x12G\n" +
+	"\x10parameterization\x18\x03 \x01(\v2\x1b.linear.v1.ParameterizationR\x10parameterization*\x81\x01\n" +
+	"\fSolutionKind\x12\x1d\n" +
+	"\x19SOLUTION_KIND_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SOLUTION_KIND_NONE\x10\x01\x12\x17\n" +
+	"\x13SOLUTION_KIND_POINT\x10\x02\x12!\n" +
+	"\x1dSOLUTION_KIND_AFFINE_SUBSPACE\x10\x03B&Z$github.com/a-h/linear/proto/linearpbb\x06proto3"
+
+var (
+	file_proto_linear_proto_rawDescOnce sync.Once
+	file_proto_linear_proto_rawDescData []byte
+)
+
+func file_proto_linear_proto_rawDescGZIP() []byte {
+	file_proto_linear_proto_rawDescOnce.Do(func() {
+		file_proto_linear_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_linear_proto_rawDesc), len(file_proto_linear_proto_rawDesc)))
+	})
+	return file_proto_linear_proto_rawDescData
+}
+
+var file_proto_linear_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_linear_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_linear_proto_goTypes = []any{
+	(SolutionKind)(0),        // 0: linear.v1.SolutionKind
+	(*Vector)(nil),           // 1: linear.v1.Vector
+	(*Equation)(nil),         // 2: linear.v1.Equation
+	(*System)(nil),           // 3: linear.v1.System
+	(*Parameterization)(nil), // 4: linear.v1.Parameterization
+	(*Solution)(nil),         // 5: linear.v1.Solution
+}
+var file_proto_linear_proto_depIdxs = []int32{
+	1, // 0: linear.v1.Equation.normal_vector:type_name -> linear.v1.Vector
+	2, // 1: linear.v1.System.equations:type_name -> linear.v1.Equation
+	1, // 2: linear.v1.Parameterization.basepoint:type_name -> linear.v1.Vector
+	1, // 3: linear.v1.Parameterization.direction_vectors:type_name -> linear.v1.Vector
+	0, // 4: linear.v1.Solution.kind:type_name -> linear.v1.SolutionKind
+	4, // 5: linear.v1.Solution.parameterization:type_name -> linear.v1.Parameterization
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_linear_proto_init() }
+func file_proto_linear_proto_init() {
+	if File_proto_linear_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_linear_proto_rawDesc), len(file_proto_linear_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_linear_proto_goTypes,
+		DependencyIndexes: file_proto_linear_proto_depIdxs,
+		EnumInfos:         file_proto_linear_proto_enumTypes,
+		MessageInfos:      file_proto_linear_proto_msgTypes,
+	}.Build()
+	File_proto_linear_proto = out.File
+	file_proto_linear_proto_goTypes = nil
+	file_proto_linear_proto_depIdxs = nil
+}
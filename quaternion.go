@@ -0,0 +1,256 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// Quaternion is a number of the form w + xi + yj + zk. Unit quaternions represent rotations in 3D space, and avoid the
+// gimbal lock of Euler angles and the drift of repeatedly multiplied rotation matrices.
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewQuaternion creates a quaternion from its scalar (w) and vector (x, y, z) parts.
+func NewQuaternion(w, x, y, z float64) Quaternion {
+	return Quaternion{W: w, X: x, Y: y, Z: z}
+}
+
+// IdentityQuaternion returns the quaternion which represents no rotation.
+func IdentityQuaternion() Quaternion {
+	return NewQuaternion(1, 0, 0, 0)
+}
+
+// NewQuaternionFromAxisAngle creates a unit quaternion which represents a rotation of angle around the 3D axis. The
+// rotation is anticlockwise when looking from the end of the axis towards the origin (the right hand rule).
+func NewQuaternionFromAxisAngle(axis Vector, angle Radian) (Quaternion, error) {
+	if len(axis) != 3 {
+		return Quaternion{}, fmt.Errorf("the axis of rotation has %d dimensions but must have 3", len(axis))
+	}
+	if axis.IsZeroVector() {
+		return Quaternion{}, errors.New("the axis of rotation cannot be the zero vector")
+	}
+	u := axis.Normalize()
+	half := float64(angle) / 2
+	s := math.Sin(half)
+	return NewQuaternion(math.Cos(half), u[0]*s, u[1]*s, u[2]*s), nil
+}
+
+// NewQuaternionFromEuler creates a unit quaternion from Tait-Bryan angles, where the rotation is applied as a roll
+// around the x axis, then a pitch around the y axis, then a yaw around the z axis.
+func NewQuaternionFromEuler(roll, pitch, yaw Radian) Quaternion {
+	cr, sr := math.Cos(float64(roll)/2), math.Sin(float64(roll)/2)
+	cp, sp := math.Cos(float64(pitch)/2), math.Sin(float64(pitch)/2)
+	cy, sy := math.Cos(float64(yaw)/2), math.Sin(float64(yaw)/2)
+	return NewQuaternion(
+		cr*cp*cy+sr*sp*sy,
+		sr*cp*cy-cr*sp*sy,
+		cr*sp*cy+sr*cp*sy,
+		cr*cp*sy-sr*sp*cy)
+}
+
+// NewQuaternionFromRotationMatrix creates a unit quaternion from a 3x3 rotation matrix, where m[row][column]. The
+// matrix must be orthonormal with a determinant of 1.
+func NewQuaternionFromRotationMatrix(m [3][3]float64) (Quaternion, error) {
+	if !isRotationMatrix(m) {
+		return Quaternion{}, errors.New("the matrix is not a rotation matrix because it is not orthonormal with a determinant of 1")
+	}
+	// Use the largest of the diagonal terms to avoid dividing by a number close to zero.
+	trace := m[0][0] + m[1][1] + m[2][2]
+	var q Quaternion
+	switch {
+	case trace > 0:
+		s := math.Sqrt(trace+1) * 2
+		q = NewQuaternion(s/4, (m[2][1]-m[1][2])/s, (m[0][2]-m[2][0])/s, (m[1][0]-m[0][1])/s)
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := math.Sqrt(1+m[0][0]-m[1][1]-m[2][2]) * 2
+		q = NewQuaternion((m[2][1]-m[1][2])/s, s/4, (m[0][1]+m[1][0])/s, (m[0][2]+m[2][0])/s)
+	case m[1][1] > m[2][2]:
+		s := math.Sqrt(1+m[1][1]-m[0][0]-m[2][2]) * 2
+		q = NewQuaternion((m[0][2]-m[2][0])/s, (m[0][1]+m[1][0])/s, s/4, (m[1][2]+m[2][1])/s)
+	default:
+		s := math.Sqrt(1+m[2][2]-m[0][0]-m[1][1]) * 2
+		q = NewQuaternion((m[1][0]-m[0][1])/s, (m[0][2]+m[2][0])/s, (m[1][2]+m[2][1])/s, s/4)
+	}
+	return q.Normalize(), nil
+}
+
+func isRotationMatrix(m [3][3]float64) bool {
+	rows := [3]Vector{m[0][:], m[1][:], m[2][:]}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			dp, _ := rows[i].DotProduct(rows[j])
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if !tolerance.IsWithin(dp, expected, 1e-9) {
+				return false
+			}
+		}
+	}
+	det := determinant([][]float64{m[0][:], m[1][:], m[2][:]})
+	return tolerance.IsWithin(det, 1, 1e-9)
+}
+
+// String writes out the quaternion, e.g. 1 - 2i + 3.5j + 0k
+func (q Quaternion) String() string {
+	return Formatter{}.Quaternion(q)
+}
+
+// Eq compares an input quaternion against the current quaternion. Note that q and -q represent the same rotation but
+// are not equal.
+func (q Quaternion) Eq(q2 Quaternion) bool {
+	return q.EqWithinTolerance(q2, DefaultTolerance)
+}
+
+// EqWithinTolerance tests that a quaternion is equal, within a given tolerance.
+func (q Quaternion) EqWithinTolerance(q2 Quaternion, t float64) bool {
+	return tolerance.IsWithin(q.W, q2.W, t) &&
+		tolerance.IsWithin(q.X, q2.X, t) &&
+		tolerance.IsWithin(q.Y, q2.Y, t) &&
+		tolerance.IsWithin(q.Z, q2.Z, t)
+}
+
+// Vector returns the x, y and z parts of the quaternion as a 3D vector.
+func (q Quaternion) Vector() Vector {
+	return NewVector(q.X, q.Y, q.Z)
+}
+
+// Mul calculates the Hamilton product of the current quaternion and q2. When both are rotations, the result is the
+// rotation q2 followed by the current rotation.
+func (q Quaternion) Mul(q2 Quaternion) Quaternion {
+	return NewQuaternion(
+		q.W*q2.W-q.X*q2.X-q.Y*q2.Y-q.Z*q2.Z,
+		q.W*q2.X+q.X*q2.W+q.Y*q2.Z-q.Z*q2.Y,
+		q.W*q2.Y-q.X*q2.Z+q.Y*q2.W+q.Z*q2.X,
+		q.W*q2.Z+q.X*q2.Y-q.Y*q2.X+q.Z*q2.W)
+}
+
+// Scale multiplies each part of the quaternion by the scalar input and returns a new quaternion.
+func (q Quaternion) Scale(scalar float64) Quaternion {
+	return NewQuaternion(q.W*scalar, q.X*scalar, q.Y*scalar, q.Z*scalar)
+}
+
+// Conjugate negates the vector part of the quaternion. For a unit quaternion, the conjugate is the inverse rotation.
+func (q Quaternion) Conjugate() Quaternion {
+	return NewQuaternion(q.W, -q.X, -q.Y, -q.Z)
+}
+
+// Norm calculates the magnitude of the quaternion.
+func (q Quaternion) Norm() float64 {
+	return NewVector(q.W, q.X, q.Y, q.Z).Magnitude()
+}
+
+// Normalize normalizes the magnitude of the quaternion to 1 and returns a new quaternion.
+func (q Quaternion) Normalize() Quaternion {
+	n := q.Norm()
+	if n == 0 {
+		return Quaternion{}
+	}
+	return q.Scale(1 / n)
+}
+
+// Inverse calculates the quaternion which, when multiplied by the current quaternion, gives the identity.
+func (q Quaternion) Inverse() (Quaternion, error) {
+	n := NewVector(q.W, q.X, q.Y, q.Z)
+	normSquared, _ := n.DotProduct(n)
+	if normSquared == 0 {
+		return Quaternion{}, errors.New("the zero quaternion does not have an inverse")
+	}
+	return q.Conjugate().Scale(1 / normSquared), nil
+}
+
+// Rotate rotates the 3D vector v by the rotation represented by the current quaternion. The quaternion is normalized
+// before use.
+func (q Quaternion) Rotate(v Vector) (Vector, error) {
+	if len(v) != 3 {
+		return Vector{}, fmt.Errorf("the vector has %d dimensions but must have 3 to be rotated", len(v))
+	}
+	u := q.Normalize()
+	if u == (Quaternion{}) {
+		return Vector{}, errors.New("the zero quaternion does not represent a rotation")
+	}
+	p := NewQuaternion(0, v[0], v[1], v[2])
+	return u.Mul(p).Mul(u.Conjugate()).Vector(), nil
+}
+
+// AxisAngle returns the unit axis and angle of the rotation represented by the quaternion. For the identity rotation,
+// the axis is the x axis and the angle is zero.
+func (q Quaternion) AxisAngle() (axis Vector, angle Radian) {
+	u := q.Normalize()
+	// Keep the angle in the range [0, π].
+	if u.W < 0 {
+		u = u.Scale(-1)
+	}
+	s := math.Sqrt(math.Max(1-u.W*u.W, 0))
+	if tolerance.IsWithin(s, 0, DefaultTolerance) {
+		return NewVector(1, 0, 0), 0
+	}
+	return NewVector(u.X/s, u.Y/s, u.Z/s), Radian(2 * math.Acos(math.Min(u.W, 1)))
+}
+
+// Euler returns the roll (around the x axis), pitch (around the y axis) and yaw (around the z axis) of the rotation
+// represented by the quaternion, in the same convention as NewQuaternionFromEuler. When the pitch is ±90 degrees,
+// roll and yaw describe the same rotation, so the roll is set to zero.
+func (q Quaternion) Euler() (roll, pitch, yaw Radian) {
+	u := q.Normalize()
+	sinPitch := 2 * (u.W*u.Y - u.Z*u.X)
+	if math.Abs(sinPitch) >= 1-DefaultTolerance {
+		pitch = Radian(math.Copysign(math.Pi/2, sinPitch))
+		yaw = Radian(-2 * math.Atan2(u.X, u.W) * math.Copysign(1, sinPitch))
+		return 0, pitch, yaw
+	}
+	roll = Radian(math.Atan2(2*(u.W*u.X+u.Y*u.Z), 1-2*(u.X*u.X+u.Y*u.Y)))
+	pitch = Radian(math.Asin(sinPitch))
+	yaw = Radian(math.Atan2(2*(u.W*u.Z+u.X*u.Y), 1-2*(u.Y*u.Y+u.Z*u.Z)))
+	return roll, pitch, yaw
+}
+
+// RotationMatrix returns the 3x3 rotation matrix, where m[row][column], which rotates a column vector in the same way
+// as the quaternion. The quaternion is normalized before use.
+func (q Quaternion) RotationMatrix() [3][3]float64 {
+	u := q.Normalize()
+	w, x, y, z := u.W, u.X, u.Y, u.Z
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// Slerp uses spherical linear interpolation to find the rotation a fraction t of the way from the current rotation to
+// q2, at a constant angular velocity and along the shortest path. A t of 0 returns the current rotation, and a t of 1
+// returns q2.
+func (q Quaternion) Slerp(q2 Quaternion, t float64) Quaternion {
+	a, b := q.Normalize(), q2.Normalize()
+	cosTheta := a.W*b.W + a.X*b.X + a.Y*b.Y + a.Z*b.Z
+	// q and -q are the same rotation, so take the shortest path.
+	if cosTheta < 0 {
+		b = b.Scale(-1)
+		cosTheta = -cosTheta
+	}
+	// When the rotations are very close, sin(θ) tends to zero, so use linear interpolation instead.
+	if cosTheta > 1-DefaultTolerance {
+		return NewQuaternion(
+			a.W+t*(b.W-a.W),
+			a.X+t*(b.X-a.X),
+			a.Y+t*(b.Y-a.Y),
+			a.Z+t*(b.Z-a.Z)).Normalize()
+	}
+	theta := math.Acos(cosTheta)
+	sinTheta := math.Sin(theta)
+	wa := math.Sin((1-t)*theta) / sinTheta
+	wb := math.Sin(t*theta) / sinTheta
+	return NewQuaternion(
+		wa*a.W+wb*b.W,
+		wa*a.X+wb*b.X,
+		wa*a.Y+wb*b.Y,
+		wa*a.Z+wb*b.Z)
+}
@@ -0,0 +1,331 @@
+package linear
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+func TestQuaternionStringRepresentation(t *testing.T) {
+	actual := NewQuaternion(1, -2, 3.5, 0).String()
+	expected := "1 - 2i + 3.5j + 0k"
+	if actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestQuaternionMultiplication(t *testing.T) {
+	i := NewQuaternion(0, 1, 0, 0)
+	j := NewQuaternion(0, 0, 1, 0)
+	k := NewQuaternion(0, 0, 0, 1)
+	minusOne := NewQuaternion(-1, 0, 0, 0)
+
+	tests := []struct {
+		name     string
+		a        Quaternion
+		b        Quaternion
+		expected Quaternion
+	}{
+		{name: "i² = -1", a: i, b: i, expected: minusOne},
+		{name: "j² = -1", a: j, b: j, expected: minusOne},
+		{name: "k² = -1", a: k, b: k, expected: minusOne},
+		{name: "ij = k", a: i, b: j, expected: k},
+		{name: "ji = -k", a: j, b: i, expected: k.Scale(-1)},
+		{name: "jk = i", a: j, b: k, expected: i},
+		{name: "ki = j", a: k, b: i, expected: j},
+		{name: "identity", a: IdentityQuaternion(), b: NewQuaternion(1, 2, 3, 4), expected: NewQuaternion(1, 2, 3, 4)},
+		{name: "general", a: NewQuaternion(1, 2, 3, 4), b: NewQuaternion(5, 6, 7, 8), expected: NewQuaternion(-60, 12, 30, 24)},
+	}
+
+	for _, test := range tests {
+		actual := test.a.Mul(test.b)
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestQuaternionNormAndInverse(t *testing.T) {
+	q := NewQuaternion(1, 2, 3, 4)
+	if actual := q.Norm(); !tolerance.IsWithin(actual, math.Sqrt(30), DefaultTolerance) {
+		t.Errorf("expected the norm to be √30, but got %v", actual)
+	}
+	if actual := q.Normalize().Norm(); !tolerance.IsWithin(actual, 1, DefaultTolerance) {
+		t.Errorf("expected the normalized norm to be 1, but got %v", actual)
+	}
+	if actual := q.Conjugate(); !actual.Eq(NewQuaternion(1, -2, -3, -4)) {
+		t.Errorf("expected the conjugate to be 1 - 2i - 3j - 4k, but got %v", actual)
+	}
+	inverse, err := q.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := q.Mul(inverse); !actual.Eq(IdentityQuaternion()) {
+		t.Errorf("expected q * q⁻¹ to be the identity, but got %v", actual)
+	}
+	if _, err := (Quaternion{}).Inverse(); err == nil {
+		t.Errorf("expected an error calculating the inverse of the zero quaternion")
+	}
+}
+
+func TestQuaternionRotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		axis     Vector
+		angle    Radian
+		v        Vector
+		expected Vector
+	}{
+		{
+			name:     "90 degrees around z",
+			axis:     NewVector(0, 0, 1),
+			angle:    NewRadian(90),
+			v:        NewVector(1, 0, 0),
+			expected: NewVector(0, 1, 0),
+		},
+		{
+			name:     "90 degrees around x",
+			axis:     NewVector(2, 0, 0),
+			angle:    NewRadian(90),
+			v:        NewVector(0, 1, 0),
+			expected: NewVector(0, 0, 1),
+		},
+		{
+			name:     "180 degrees around y",
+			axis:     NewVector(0, 1, 0),
+			angle:    NewRadian(180),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(-1, 2, -3),
+		},
+		{
+			name:     "120 degrees around the diagonal cycles the axes",
+			axis:     NewVector(1, 1, 1),
+			angle:    NewRadian(120),
+			v:        NewVector(1, 0, 0),
+			expected: NewVector(0, 1, 0),
+		},
+		{
+			name:     "rotating a vector on the axis does nothing",
+			axis:     NewVector(1, 1, 1),
+			angle:    NewRadian(33),
+			v:        NewVector(2, 2, 2),
+			expected: NewVector(2, 2, 2),
+		},
+	}
+
+	for _, test := range tests {
+		q, err := NewQuaternionFromAxisAngle(test.axis, test.angle)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		actual, err := q.Rotate(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+
+		// The rotation matrix should give the same result.
+		m := q.RotationMatrix()
+		var fromMatrix Vector
+		for _, row := range m {
+			dp, _ := Vector(row[:]).DotProduct(test.v)
+			fromMatrix = append(fromMatrix, dp)
+		}
+		if !fromMatrix.Eq(test.expected) {
+			t.Errorf("%s: expected the rotation matrix to give %v, but got %v", test.name, test.expected, fromMatrix)
+		}
+
+		// And the axis and angle can be recovered.
+		axis, angle := q.AxisAngle()
+		if !tolerance.IsWithin(float64(angle), float64(test.angle), DefaultTolerance) {
+			t.Errorf("%s: expected the angle to be %v, but got %v", test.name, test.angle, angle)
+		}
+		if !axis.Eq(test.axis.Normalize()) {
+			t.Errorf("%s: expected the axis to be %v, but got %v", test.name, test.axis.Normalize(), axis)
+		}
+	}
+}
+
+func TestQuaternionErrors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		f                    func() error
+		expectedErrorMessage string
+	}{
+		{
+			name: "2D axis",
+			f: func() error {
+				_, err := NewQuaternionFromAxisAngle(NewVector(1, 0), 1)
+				return err
+			},
+			expectedErrorMessage: "the axis of rotation has 2 dimensions but must have 3",
+		},
+		{
+			name: "zero axis",
+			f: func() error {
+				_, err := NewQuaternionFromAxisAngle(NewVector(0, 0, 0), 1)
+				return err
+			},
+			expectedErrorMessage: "the axis of rotation cannot be the zero vector",
+		},
+		{
+			name: "rotate a 2D vector",
+			f: func() error {
+				_, err := IdentityQuaternion().Rotate(NewVector(1, 0))
+				return err
+			},
+			expectedErrorMessage: "the vector has 2 dimensions but must have 3 to be rotated",
+		},
+		{
+			name: "rotate using the zero quaternion",
+			f: func() error {
+				_, err := Quaternion{}.Rotate(NewVector(1, 0, 0))
+				return err
+			},
+			expectedErrorMessage: "the zero quaternion does not represent a rotation",
+		},
+		{
+			name: "matrix is not a rotation",
+			f: func() error {
+				_, err := NewQuaternionFromRotationMatrix([3][3]float64{{2, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+				return err
+			},
+			expectedErrorMessage: "the matrix is not a rotation matrix",
+		},
+		{
+			name: "matrix is a reflection",
+			f: func() error {
+				_, err := NewQuaternionFromRotationMatrix([3][3]float64{{-1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+				return err
+			},
+			expectedErrorMessage: "the matrix is not a rotation matrix",
+		},
+	}
+
+	for _, test := range tests {
+		err := test.f()
+		if err == nil || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+			t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+		}
+	}
+}
+
+func TestQuaternionRotationMatrixRoundTrip(t *testing.T) {
+	axes := []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1), NewVector(1, -2, 3)}
+	for _, axis := range axes {
+		for _, degrees := range []float64{0, 30, 90, 179, 180, 270} {
+			q, _ := NewQuaternionFromAxisAngle(axis, NewRadian(degrees))
+			actual, err := NewQuaternionFromRotationMatrix(q.RotationMatrix())
+			if err != nil {
+				t.Errorf("%v around %v: unexpected error: %v", degrees, axis, err)
+				continue
+			}
+			// q and -q are the same rotation.
+			if !actual.EqWithinTolerance(q, 1e-9) && !actual.EqWithinTolerance(q.Scale(-1), 1e-9) {
+				t.Errorf("%v around %v: expected %v, but got %v", degrees, axis, q, actual)
+			}
+		}
+	}
+}
+
+func TestQuaternionFromEuler(t *testing.T) {
+	tests := []struct {
+		name     string
+		roll     Radian
+		pitch    Radian
+		yaw      Radian
+		v        Vector
+		expected Vector
+	}{
+		{
+			name:     "yaw only",
+			yaw:      NewRadian(90),
+			v:        NewVector(1, 0, 0),
+			expected: NewVector(0, 1, 0),
+		},
+		{
+			name:     "pitch only",
+			pitch:    NewRadian(90),
+			v:        NewVector(1, 0, 0),
+			expected: NewVector(0, 0, -1),
+		},
+		{
+			name:     "roll only",
+			roll:     NewRadian(90),
+			v:        NewVector(0, 1, 0),
+			expected: NewVector(0, 0, 1),
+		},
+		{
+			name:     "roll then yaw",
+			roll:     NewRadian(90),
+			yaw:      NewRadian(90),
+			v:        NewVector(0, 1, 0),
+			expected: NewVector(0, 0, 1),
+		},
+		{
+			name:     "roll then pitch",
+			roll:     NewRadian(90),
+			pitch:    NewRadian(90),
+			v:        NewVector(0, 1, 0),
+			expected: NewVector(1, 0, 0),
+		},
+	}
+
+	for _, test := range tests {
+		q := NewQuaternionFromEuler(test.roll, test.pitch, test.yaw)
+		actual, _ := q.Rotate(test.v)
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+
+		// Converting back to Euler angles gives the same rotation.
+		roll, pitch, yaw := q.Euler()
+		back, _ := NewQuaternionFromEuler(roll, pitch, yaw).Rotate(NewVector(1, 2, 3))
+		expected, _ := q.Rotate(NewVector(1, 2, 3))
+		if !back.EqWithinTolerance(expected, 1e-6) {
+			t.Errorf("%s: expected the Euler angles (%v, %v, %v) to give %v, but got %v", test.name, roll, pitch, yaw, expected, back)
+		}
+	}
+}
+
+func TestQuaternionSlerp(t *testing.T) {
+	z := NewVector(0, 0, 1)
+	from, _ := NewQuaternionFromAxisAngle(z, 0)
+	to, _ := NewQuaternionFromAxisAngle(z, NewRadian(90))
+
+	tests := []struct {
+		t        float64
+		expected Radian
+	}{
+		{t: 0, expected: 0},
+		{t: 0.25, expected: NewRadian(22.5)},
+		{t: 0.5, expected: NewRadian(45)},
+		{t: 1, expected: NewRadian(90)},
+	}
+
+	for _, test := range tests {
+		actual := from.Slerp(to, test.t)
+		expected, _ := NewQuaternionFromAxisAngle(z, test.expected)
+		if !actual.Eq(expected) {
+			t.Errorf("for t = %v, expected %v, but got %v", test.t, expected, actual)
+		}
+	}
+
+	// The shortest path is taken, even if one of the quaternions is negated.
+	actual := from.Slerp(to.Scale(-1), 0.5)
+	expected, _ := NewQuaternionFromAxisAngle(z, NewRadian(45))
+	if !actual.Eq(expected) {
+		t.Errorf("for the negated target, expected %v, but got %v", expected, actual)
+	}
+
+	// Nearly identical rotations don't divide by zero.
+	if actual := from.Slerp(from, 0.5); !actual.Eq(from) {
+		t.Errorf("for identical rotations, expected %v, but got %v", from, actual)
+	}
+}
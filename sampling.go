@@ -0,0 +1,238 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// maxRejections is the number of consecutive random points which can fall outside of a bounding box before sampling
+// gives up.
+const maxRejections = 10000
+
+// Interval is the closed range of values from Min to Max.
+type Interval struct {
+	Min float64
+	Max float64
+}
+
+// NewInterval creates an interval from min to max.
+func NewInterval(min, max float64) Interval {
+	return Interval{Min: min, Max: max}
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Min, i.Max)
+}
+
+// Contains determines whether v is within the interval.
+func (i Interval) Contains(v float64) bool {
+	return v >= i.Min-DefaultTolerance && v <= i.Max+DefaultTolerance
+}
+
+// EvenlySpaced returns count values which are evenly spaced from Min to Max, including both ends.
+func (i Interval) EvenlySpaced(count int) []float64 {
+	if count <= 0 {
+		return []float64{}
+	}
+	if count == 1 {
+		return []float64{i.Min}
+	}
+	op := make([]float64, count)
+	step := (i.Max - i.Min) / float64(count-1)
+	for j := range op {
+		op[j] = i.Min + float64(j)*step
+	}
+	// Avoid rounding errors at the end of the range.
+	op[count-1] = i.Max
+	return op
+}
+
+// BoundingBox limits each variable of a point to an interval, e.g. the first interval limits x₁, the second limits x₂.
+type BoundingBox []Interval
+
+// Contains determines whether every variable of v is within the interval with the same index.
+func (b BoundingBox) Contains(v Vector) (bool, error) {
+	if len(v) != len(b) {
+		return false, fmt.Errorf("the bounding box has %d dimensions, but the point has %d dimensions", len(b), len(v))
+	}
+	for i, interval := range b {
+		if !interval.Contains(v[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// PointIterator lazily generates points from a Parameterization. Call Next to advance to each point, and Point to read
+// it, e.g.:
+//
+//	it := p.GridIterator(values, nil)
+//	for it.Next() {
+//		fmt.Println(it.Point())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PointIterator struct {
+	p Parameterization
+	// parameters returns the next set of free variable values, or false when there are no more.
+	parameters func() (Vector, bool)
+	box        BoundingBox
+	// rejectionLimit is the number of consecutive points outside of the box which stops iteration with an error. If
+	// it's zero, points outside of the box are skipped without limit.
+	rejectionLimit int
+	point          Vector
+	err            error
+}
+
+// Next advances the iterator to the next point, returning false when there are no more points or an error occurred.
+func (it *PointIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	rejections := 0
+	for {
+		parameters, ok := it.parameters()
+		if !ok {
+			return false
+		}
+		point, err := it.p.At(parameters)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if it.box == nil {
+			it.point = point
+			return true
+		}
+		inside, err := it.box.Contains(point)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if inside {
+			it.point = point
+			return true
+		}
+		rejections++
+		if it.rejectionLimit > 0 && rejections >= it.rejectionLimit {
+			it.err = fmt.Errorf("could not find a point inside the bounding box after %d attempts", rejections)
+			return false
+		}
+	}
+}
+
+// Point returns the current point.
+func (it *PointIterator) Point() Vector {
+	return it.point
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *PointIterator) Err() error {
+	return it.err
+}
+
+func (p1 Parameterization) newPointIterator(parameters func() (Vector, bool), box BoundingBox, rejectionLimit int) *PointIterator {
+	it := &PointIterator{
+		p:              p1,
+		parameters:     parameters,
+		box:            box,
+		rejectionLimit: rejectionLimit,
+	}
+	if err := p1.validate(); err != nil {
+		it.err = err
+	} else if box != nil && len(box) != len(p1.Basepoint) {
+		it.err = fmt.Errorf("the bounding box has %d dimensions, but the parameterization has %d dimensions", len(box), len(p1.Basepoint))
+	}
+	return it
+}
+
+// GridIterator lazily generates the points for every combination of free variable values, where values[i] lists the
+// values of the free variable multiplied by the direction vector at index i. The last free variable changes fastest.
+// If box is not nil, points outside of it are skipped.
+func (p1 Parameterization) GridIterator(values [][]float64, box BoundingBox) *PointIterator {
+	indices := make([]int, len(values))
+	started, done := false, false
+	for _, v := range values {
+		if len(v) == 0 {
+			done = true
+		}
+	}
+	parameters := func() (Vector, bool) {
+		if done {
+			return Vector{}, false
+		}
+		if started {
+			// Increment the indices like an odometer.
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(values[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				done = true
+				return Vector{}, false
+			}
+		}
+		started = true
+		op := make(Vector, len(values))
+		for i, index := range indices {
+			op[i] = values[i][index]
+		}
+		return op, true
+	}
+	it := p1.newPointIterator(parameters, box, 0)
+	if it.err == nil && len(values) != len(p1.DirectionVectors) {
+		it.err = fmt.Errorf("the parameterization has %d direction vectors, but values were provided for %d free variables", len(p1.DirectionVectors), len(values))
+	}
+	return it
+}
+
+// Grid returns the points for every combination of free variable values, see GridIterator.
+func (p1 Parameterization) Grid(values [][]float64, box BoundingBox) ([]Vector, error) {
+	return collect(p1.GridIterator(values, box), -1)
+}
+
+// RandomIterator lazily generates an endless sequence of random points, where the free variable multiplied by the
+// direction vector at index i is chosen uniformly from ranges[i]. Using a source with the same seed generates the same
+// points. If box is not nil, points outside of it are rejected, and iteration stops with an error if too many
+// consecutive points are rejected.
+func (p1 Parameterization) RandomIterator(source rand.Source, ranges []Interval, box BoundingBox) *PointIterator {
+	r := rand.New(source)
+	parameters := func() (Vector, bool) {
+		op := make(Vector, len(ranges))
+		for i, interval := range ranges {
+			op[i] = interval.Min + r.Float64()*(interval.Max-interval.Min)
+		}
+		return op, true
+	}
+	it := p1.newPointIterator(parameters, box, maxRejections)
+	if it.err == nil && len(ranges) != len(p1.DirectionVectors) {
+		it.err = fmt.Errorf("the parameterization has %d direction vectors, but ranges were provided for %d free variables", len(p1.DirectionVectors), len(ranges))
+	}
+	return it
+}
+
+// Random returns count random points, see RandomIterator.
+func (p1 Parameterization) Random(source rand.Source, count int, ranges []Interval, box BoundingBox) ([]Vector, error) {
+	if count < 0 {
+		return nil, errors.New("the number of points cannot be negative")
+	}
+	return collect(p1.RandomIterator(source, ranges, box), count)
+}
+
+// collect reads up to limit points from the iterator, or all of them if limit is negative.
+func collect(it *PointIterator, limit int) ([]Vector, error) {
+	op := []Vector{}
+	for (limit < 0 || len(op) < limit) && it.Next() {
+		op = append(op, it.Point())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
@@ -0,0 +1,184 @@
+package linear
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalEvenlySpacedFunction(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		count    int
+		expected Vector
+	}{
+		{interval: NewInterval(0, 1), count: 0, expected: NewVector()},
+		{interval: NewInterval(0, 1), count: 1, expected: NewVector(0)},
+		{interval: NewInterval(0, 1), count: 2, expected: NewVector(0, 1)},
+		{interval: NewInterval(-1, 1), count: 5, expected: NewVector(-1, -0.5, 0, 0.5, 1)},
+		{interval: NewInterval(0, 0.3), count: 4, expected: NewVector(0, 0.1, 0.2, 0.3)},
+	}
+
+	for _, test := range tests {
+		actual := Vector(test.interval.EvenlySpaced(test.count))
+		if !actual.Eq(test.expected) {
+			t.Errorf("for %v with %d values, expected %v, but got %v", test.interval, test.count, test.expected, actual)
+		}
+	}
+}
+
+func TestParameterizationGridFunction(t *testing.T) {
+	plane := Parameterization{
+		Basepoint:        NewVector(1, 1, 0),
+		DirectionVectors: []Vector{NewVector(1, 0, 1), NewVector(0, 1, 1)},
+	}
+
+	tests := []struct {
+		name                 string
+		p                    Parameterization
+		values               [][]float64
+		box                  BoundingBox
+		expected             []Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:     "point",
+			p:        Parameterization{Basepoint: NewVector(1, 2)},
+			values:   [][]float64{},
+			expected: []Vector{NewVector(1, 2)},
+		},
+		{
+			name:   "line",
+			p:      Parameterization{Basepoint: NewVector(1, 2), DirectionVectors: []Vector{NewVector(1, -1)}},
+			values: [][]float64{{-1, 0, 1}},
+			expected: []Vector{
+				NewVector(0, 3),
+				NewVector(1, 2),
+				NewVector(2, 1),
+			},
+		},
+		{
+			name:   "plane",
+			p:      plane,
+			values: [][]float64{{0, 1}, {0, 2}},
+			expected: []Vector{
+				NewVector(1, 1, 0),
+				NewVector(1, 3, 2),
+				NewVector(2, 1, 1),
+				NewVector(2, 3, 3),
+			},
+		},
+		{
+			name:   "plane inside a bounding box",
+			p:      plane,
+			values: [][]float64{{0, 1}, {0, 2}},
+			box:    BoundingBox{NewInterval(0, 10), NewInterval(0, 10), NewInterval(1, 2)},
+			expected: []Vector{
+				NewVector(1, 3, 2),
+				NewVector(2, 1, 1),
+			},
+		},
+		{
+			name:     "no values for a free variable",
+			p:        plane,
+			values:   [][]float64{{0, 1}, {}},
+			expected: []Vector{},
+		},
+		{
+			name:                 "wrong number of free variables",
+			p:                    plane,
+			values:               [][]float64{{0, 1}},
+			expectedErrorMessage: "the parameterization has 2 direction vectors, but values were provided for 1 free variables",
+		},
+		{
+			name:                 "wrong bounding box dimensions",
+			p:                    plane,
+			values:               [][]float64{{0, 1}, {0, 1}},
+			box:                  BoundingBox{NewInterval(0, 10)},
+			expectedErrorMessage: "the bounding box has 1 dimensions, but the parameterization has 3 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.p.Grid(test.values, test.box)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if len(actual) != len(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if !actual[i].Eq(test.expected[i]) {
+				t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestParameterizationGridIteratorIsLazy(t *testing.T) {
+	line := Parameterization{Basepoint: NewVector(0), DirectionVectors: []Vector{NewVector(1)}}
+	it := line.GridIterator([][]float64{NewInterval(0, 99).EvenlySpaced(100)}, nil)
+	var count int
+	for it.Next() {
+		count++
+		if it.Point()[0] >= 2 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected to read 3 points, but read %d", count)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParameterizationRandomFunction(t *testing.T) {
+	plane := Parameterization{
+		Basepoint:        NewVector(0, 0, 5),
+		DirectionVectors: []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0)},
+	}
+	ranges := []Interval{NewInterval(-10, 10), NewInterval(-10, 10)}
+	box := BoundingBox{NewInterval(0, 10), NewInterval(-1, 1), NewInterval(0, 10)}
+
+	points, err := plane.Random(rand.NewSource(42), 50, ranges, box)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 50 {
+		t.Fatalf("expected 50 points, but got %d", len(points))
+	}
+	for _, p := range points {
+		if contains, _ := plane.Contains(p); !contains {
+			t.Errorf("expected %v to be on the plane", p)
+		}
+		if inside, _ := box.Contains(p); !inside {
+			t.Errorf("expected %v to be inside the bounding box", p)
+		}
+	}
+
+	// The same seed produces the same points.
+	again, _ := plane.Random(rand.NewSource(42), 50, ranges, box)
+	for i := range points {
+		if !points[i].Eq(again[i]) {
+			t.Fatalf("expected the same seed to produce the same points, but got %v and %v at index %d", points[i], again[i], i)
+		}
+	}
+
+	// A bounding box which the plane doesn't pass through can't be satisfied.
+	unreachable := BoundingBox{NewInterval(0, 1), NewInterval(0, 1), NewInterval(0, 1)}
+	if _, err := plane.Random(rand.NewSource(1), 1, ranges, unreachable); err == nil {
+		t.Errorf("expected an error when no points are inside the bounding box")
+	}
+	if _, err := plane.Random(rand.NewSource(1), 1, ranges[:1], nil); err == nil {
+		t.Errorf("expected an error when the ranges don't match the direction vectors")
+	}
+}
@@ -0,0 +1,180 @@
+package linear
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SQLFormat is the text format used to store a vector in a database column.
+type SQLFormat int
+
+const (
+	// PostgresArrayFormat is the Postgres array literal used by float8[] and real[] columns, e.g. {1,2,3}
+	PostgresArrayFormat SQLFormat = iota
+	// PGVectorFormat is the text format of the pgvector extension's vector type, e.g. [1,2,3]
+	PGVectorFormat
+)
+
+func (f SQLFormat) String() string {
+	switch f {
+	case PostgresArrayFormat:
+		return "Postgres array"
+	case PGVectorFormat:
+		return "pgvector"
+	}
+	return fmt.Sprintf("SQLFormat(%d)", int(f))
+}
+
+// Value implements the driver.Valuer interface, writing the vector as a Postgres array literal, e.g. {1,2,3}. A nil
+// vector is written as NULL. Use SQLVector to write to a pgvector column.
+func (v1 Vector) Value() (driver.Value, error) {
+	return SQLVector{Vector: v1}.Value()
+}
+
+// Scan implements the sql.Scanner interface, reading the vector from a Postgres array literal, e.g. {1,2,3}, or the
+// pgvector text format, e.g. [1,2,3]. NULL is read as a nil vector. Use SQLVector to check the number of dimensions.
+func (v1 *Vector) Scan(src interface{}) error {
+	sv := SQLVector{}
+	if err := sv.Scan(src); err != nil {
+		return err
+	}
+	*v1 = sv.Vector
+	return nil
+}
+
+// SQLVector stores a vector in a database column with a fixed format and number of dimensions, e.g. a vector(3)
+// column of the pgvector extension:
+//
+//	v := linear.SQLVector{Format: linear.PGVectorFormat, Dimension: 3}
+//	err := row.Scan(&v)
+type SQLVector struct {
+	Vector Vector
+	// Format is the format used to write the vector. Both formats are accepted when reading.
+	Format SQLFormat
+	// Dimension is the number of values which the vector must contain, or zero if any number is allowed.
+	Dimension int
+}
+
+// Value implements the driver.Valuer interface.
+func (sv SQLVector) Value() (driver.Value, error) {
+	if sv.Vector == nil {
+		return nil, nil
+	}
+	if err := sv.checkDimension(sv.Vector); err != nil {
+		return nil, err
+	}
+	var open, close string
+	switch sv.Format {
+	case PostgresArrayFormat:
+		open, close = "{", "}"
+	case PGVectorFormat:
+		open, close = "[", "]"
+	default:
+		return nil, fmt.Errorf("the SQL format %v is not supported", sv.Format)
+	}
+	var sb strings.Builder
+	sb.WriteString(open)
+	for i, value := range sv.Vector {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		switch {
+		case math.IsNaN(value) || math.IsInf(value, 0):
+			// pgvector doesn't support NaN or infinite values, but Postgres arrays do.
+			if sv.Format == PGVectorFormat {
+				return nil, fmt.Errorf("cannot write the value %v at index %d in the %v format", value, i, sv.Format)
+			}
+			sb.WriteString(postgresSpecialValue(value))
+		default:
+			sb.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+		}
+	}
+	sb.WriteString(close)
+	return sb.String(), nil
+}
+
+func postgresSpecialValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "Infinity"
+	}
+	if math.IsInf(value, -1) {
+		return "-Infinity"
+	}
+	return "NaN"
+}
+
+// Scan implements the sql.Scanner interface.
+func (sv *SQLVector) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		sv.Vector = nil
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("cannot scan a value of type %T into a vector", src)
+	}
+	v, err := parseSQLVector(s)
+	if err != nil {
+		return err
+	}
+	// Check the dimension before assigning, so that the vector is left unchanged if the scan fails.
+	if err := sv.checkDimension(v); err != nil {
+		return err
+	}
+	sv.Vector = v
+	return nil
+}
+
+func (sv SQLVector) checkDimension(v Vector) error {
+	if sv.Dimension > 0 && len(v) != sv.Dimension {
+		return fmt.Errorf("expected a vector with %d dimensions, but got %d", sv.Dimension, len(v))
+	}
+	return nil
+}
+
+// parseSQLVector reads a Postgres array literal, e.g. {1,2,3}, or the pgvector text format, e.g. [1,2,3].
+func parseSQLVector(s string) (Vector, error) {
+	s = strings.TrimSpace(s)
+	var close string
+	switch {
+	case strings.HasPrefix(s, "{"):
+		close = "}"
+	case strings.HasPrefix(s, "["):
+		close = "]"
+	}
+	if close == "" || !strings.HasSuffix(s, close) {
+		return nil, fmt.Errorf("cannot scan %q into a vector, it must be a Postgres array such as {1,2,3} or a pgvector such as [1,2,3]", s)
+	}
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	if body == "" {
+		return Vector{}, nil
+	}
+	if strings.ContainsAny(body, "{}[]") {
+		return nil, fmt.Errorf("cannot scan %q into a vector, it must have a single dimension", s)
+	}
+	parts := strings.Split(body, ",")
+	op := make(Vector, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if strings.EqualFold(part, "NULL") {
+			return nil, fmt.Errorf("cannot scan %q into a vector, the value at index %d is NULL", s, i)
+		}
+		// Postgres may quote array elements.
+		if len(part) >= 2 && strings.HasPrefix(part, `"`) && strings.HasSuffix(part, `"`) {
+			part = part[1 : len(part)-1]
+		}
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan %q into a vector, the value %q at index %d is not a number", s, part, i)
+		}
+		op[i] = value
+	}
+	return op, nil
+}
@@ -0,0 +1,192 @@
+package linear
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// The vector types must be usable as query arguments and scan destinations.
+var (
+	_ driver.Valuer = Vector{}
+	_ sql.Scanner   = &Vector{}
+	_ driver.Valuer = SQLVector{}
+	_ sql.Scanner   = &SQLVector{}
+)
+
+func TestVectorValueFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                SQLVector
+		expected             driver.Value
+		expectedErrorMessage string
+	}{
+		{
+			name:     "Postgres array",
+			input:    SQLVector{Vector: NewVector(1, -2.5, 1e-7)},
+			expected: "{1,-2.5,1e-07}",
+		},
+		{
+			name:     "pgvector",
+			input:    SQLVector{Vector: NewVector(1, -2.5, 1e-7), Format: PGVectorFormat},
+			expected: "[1,-2.5,1e-07]",
+		},
+		{
+			name:     "empty",
+			input:    SQLVector{Vector: Vector{}},
+			expected: "{}",
+		},
+		{
+			name:     "nil is NULL",
+			input:    SQLVector{},
+			expected: nil,
+		},
+		{
+			name:     "special values in a Postgres array",
+			input:    SQLVector{Vector: NewVector(math.Inf(1), math.Inf(-1), math.NaN())},
+			expected: "{Infinity,-Infinity,NaN}",
+		},
+		{
+			name:                 "special values in a pgvector",
+			input:                SQLVector{Vector: NewVector(1, math.NaN()), Format: PGVectorFormat},
+			expectedErrorMessage: "cannot write the value NaN at index 1 in the pgvector format",
+		},
+		{
+			name:                 "wrong dimension",
+			input:                SQLVector{Vector: NewVector(1, 2), Dimension: 3},
+			expectedErrorMessage: "expected a vector with 3 dimensions, but got 2",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.input.Value()
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+
+	if actual, _ := NewVector(1, 2).Value(); actual != "{1,2}" {
+		t.Errorf("expected {1,2}, but got %v", actual)
+	}
+}
+
+func TestVectorScanFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                interface{}
+		dimension            int
+		expected             Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:     "Postgres array",
+			input:    "{1,-2.5,3e2}",
+			expected: NewVector(1, -2.5, 300),
+		},
+		{
+			name:     "pgvector bytes",
+			input:    []byte("[1, -2.5, 3e2]"),
+			expected: NewVector(1, -2.5, 300),
+		},
+		{
+			name:     "quoted elements",
+			input:    `{"1","2"}`,
+			expected: NewVector(1, 2),
+		},
+		{
+			name:     "empty array",
+			input:    "{}",
+			expected: Vector{},
+		},
+		{
+			name:     "NULL",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:      "matching dimension",
+			input:     "[1,2,3]",
+			dimension: 3,
+			expected:  NewVector(1, 2, 3),
+		},
+		{
+			name:                 "wrong dimension",
+			input:                "[1,2]",
+			dimension:            3,
+			expectedErrorMessage: "expected a vector with 3 dimensions, but got 2",
+		},
+		{
+			name:                 "NULL element",
+			input:                "{1,NULL}",
+			expectedErrorMessage: "cannot scan \"{1,NULL}\" into a vector, the value at index 1 is NULL",
+		},
+		{
+			name:                 "multi-dimensional array",
+			input:                "{{1,2},{3,4}}",
+			expectedErrorMessage: "cannot scan \"{{1,2},{3,4}}\" into a vector, it must have a single dimension",
+		},
+		{
+			name:                 "not a number",
+			input:                "{1,a}",
+			expectedErrorMessage: "cannot scan \"{1,a}\" into a vector, the value \"a\" at index 1 is not a number",
+		},
+		{
+			name:                 "mismatched brackets",
+			input:                "{1,2]",
+			expectedErrorMessage: "cannot scan \"{1,2]\" into a vector, it must be a Postgres array such as {1,2,3} or a pgvector such as [1,2,3]",
+		},
+		{
+			name:                 "unsupported type",
+			input:                int64(1),
+			expectedErrorMessage: "cannot scan a value of type int64 into a vector",
+		},
+	}
+
+	for _, test := range tests {
+		actual := SQLVector{Dimension: test.dimension}
+		err := actual.Scan(test.input)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("%s: expected error '%v', but got '%v'", test.name, test.expectedErrorMessage, err)
+			}
+			if actual.Vector != nil {
+				t.Errorf("%s: expected the vector to be unchanged after an error, but got %v", test.name, actual.Vector)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+			continue
+		}
+		if !reflect.DeepEqual(actual.Vector, test.expected) {
+			t.Errorf("%s: expected %#v, but got %#v", test.name, test.expected, actual.Vector)
+		}
+	}
+}
+
+func TestVectorSQLRoundTrip(t *testing.T) {
+	input := NewVector(1, -2.5, 0.30000000000000004, 1e21, math.Inf(-1))
+	value, err := input.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actual Vector
+	if err := actual.Scan(value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, input) {
+		t.Errorf("expected %v, but got %v", input, actual)
+	}
+}
@@ -0,0 +1,106 @@
+package linear
+
+import "math"
+
+// Summation is an algorithm used to add together a series of floating point values.
+type Summation int
+
+const (
+	// NaiveSummation adds each value to a running total in turn. It is the fastest method, but the rounding error
+	// grows with the number of values, and large values of opposite sign can cancel out smaller values entirely.
+	NaiveSummation Summation = iota
+	// CompensatedSummation uses the Kahan-Babuška-Neumaier algorithm to keep track of the low order bits lost when
+	// adding to the running total, and adds them back at the end. It is the default used by DotProduct.
+	CompensatedSummation
+	// PairwiseSummation recursively splits the values in half and adds the sums of each half together, so that the
+	// rounding error grows with the logarithm of the number of values rather than linearly.
+	PairwiseSummation
+)
+
+func (s Summation) String() string {
+	switch s {
+	case NaiveSummation:
+		return "naive"
+	case CompensatedSummation:
+		return "compensated"
+	case PairwiseSummation:
+		return "pairwise"
+	}
+	return "unknown"
+}
+
+// Sum adds together the elements of the vector using compensated summation.
+func (v1 Vector) Sum() float64 {
+	return v1.SumUsing(CompensatedSummation)
+}
+
+// SumUsing adds together the elements of the vector using the given summation algorithm. Unknown algorithms fall back
+// to compensated summation.
+func (v1 Vector) SumUsing(s Summation) float64 {
+	switch s {
+	case NaiveSummation:
+		return naiveSum(v1)
+	case PairwiseSummation:
+		return pairwiseSum(v1)
+	}
+	return neumaierSum(v1, nil)
+}
+
+func naiveSum(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// neumaierSum adds the values together, keeping track of the error introduced by each addition. The optional
+// corrections are the rounding errors from the calculation of each value (e.g. from a multiplication) and are added to
+// the compensation term.
+func neumaierSum(values []float64, corrections []float64) float64 {
+	var n neumaier
+	for i, v := range values {
+		n.add(v)
+		if corrections != nil {
+			n.compensation += corrections[i]
+		}
+	}
+	return n.result()
+}
+
+// neumaier is the running state of the Kahan-Babuška-Neumaier algorithm, so that values can be added as they are
+// calculated without storing them.
+type neumaier struct {
+	sum, compensation float64
+}
+
+// add adds v to the sum, keeping track of the error introduced by the addition.
+func (n *neumaier) add(v float64) {
+	t := n.sum + v
+	if math.Abs(n.sum) >= math.Abs(v) {
+		n.compensation += (n.sum - t) + v
+	} else {
+		n.compensation += (v - t) + n.sum
+	}
+	n.sum = t
+}
+
+// result returns the sum, corrected by the compensation term.
+func (n *neumaier) result() float64 {
+	if math.IsInf(n.sum, 0) || math.IsNaN(n.sum) {
+		// The compensation is meaningless once the sum has overflowed.
+		return n.sum
+	}
+	return n.sum + n.compensation
+}
+
+// pairwiseBlockSize is the number of values below which pairwiseSum adds values naively.
+const pairwiseBlockSize = 8
+
+func pairwiseSum(values []float64) float64 {
+	if len(values) <= pairwiseBlockSize {
+		return naiveSum(values)
+	}
+	mid := len(values) / 2
+	return pairwiseSum(values[:mid]) + pairwiseSum(values[mid:])
+}
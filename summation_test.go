@@ -0,0 +1,170 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummationFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Vector
+		expected float64
+	}{
+		{
+			name:     "Empty",
+			input:    NewVector(),
+			expected: 0,
+		},
+		{
+			name:     "Integers",
+			input:    NewVector(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+			expected: 78,
+		},
+		{
+			name:     "Negatives",
+			input:    NewVector(-1, 2, -3, 4),
+			expected: 2,
+		},
+	}
+
+	for _, test := range tests {
+		for _, s := range []Summation{NaiveSummation, CompensatedSummation, PairwiseSummation} {
+			actual := test.input.SumUsing(s)
+			if actual != test.expected {
+				t.Errorf("%s: for the %v sum of %v, expected %v, but got %v", test.name, s, test.input, test.expected, actual)
+			}
+		}
+	}
+}
+
+func TestCompensatedSummationIsMoreAccurateThanNaiveSummation(t *testing.T) {
+	// Naive summation loses the 1 entirely, because 1e16 + 1 can't be represented.
+	input := NewVector(1e16, 1, -1e16)
+	if actual := input.SumUsing(NaiveSummation); actual != 0 {
+		t.Errorf("expected naive summation to lose the small value, but got %v", actual)
+	}
+	if actual := input.Sum(); actual != 1 {
+		t.Errorf("expected compensated summation to return 1, but got %v", actual)
+	}
+}
+
+func TestPairwiseSummationIsMoreAccurateThanNaiveSummation(t *testing.T) {
+	// 0.1 can't be represented exactly, so the rounding error in the running total grows with each addition.
+	const n = 1000000
+	input := make(Vector, n)
+	for i := range input {
+		input[i] = 0.1
+	}
+	expected := float64(n) * 0.1
+
+	naiveError := math.Abs(input.SumUsing(NaiveSummation) - expected)
+	pairwiseError := math.Abs(input.SumUsing(PairwiseSummation) - expected)
+	compensatedError := math.Abs(input.SumUsing(CompensatedSummation) - expected)
+
+	if pairwiseError >= naiveError {
+		t.Errorf("expected the pairwise error (%v) to be less than the naive error (%v)", pairwiseError, naiveError)
+	}
+	if compensatedError >= naiveError {
+		t.Errorf("expected the compensated error (%v) to be less than the naive error (%v)", compensatedError, naiveError)
+	}
+}
+
+func TestDotProductWithCancellation(t *testing.T) {
+	a := NewVector(1e16, 1, -1e16)
+	b := NewVector(1, 1, 1)
+
+	naive, err := a.DotProductUsing(b, NaiveSummation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if naive != 0 {
+		t.Errorf("expected the naive dot product to lose the small value, but got %v", naive)
+	}
+
+	actual, err := a.DotProduct(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != 1 {
+		t.Errorf("expected the dot product to be 1, but got %v", actual)
+	}
+}
+
+func TestDotProductIncludesMultiplicationError(t *testing.T) {
+	// (1 + 2⁻³⁰)² = 1 + 2⁻²⁹ + 2⁻⁶⁰, but the 2⁻⁶⁰ is lost when the product is rounded to a float64.
+	x := 1 + math.Ldexp(1, -30)
+	a := NewVector(x, -1)
+	b := NewVector(x, 1+math.Ldexp(1, -29))
+
+	naive, _ := a.DotProductUsing(b, NaiveSummation)
+	if naive != 0 {
+		t.Errorf("expected the naive dot product to be 0, but got %v", naive)
+	}
+	actual, _ := a.DotProduct(b)
+	if expected := math.Ldexp(1, -60); actual != expected {
+		t.Errorf("expected the dot product to be %v, but got %v", expected, actual)
+	}
+}
+
+func TestDotProductOverflow(t *testing.T) {
+	actual, err := NewVector(1e200, 1).DotProduct(NewVector(1e200, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(actual, 1) {
+		t.Errorf("expected an overflowing dot product to be +Inf, but got %v", actual)
+	}
+}
+
+func TestDotProductDoesNotAllocate(t *testing.T) {
+	v1, v2 := NewVector(1, 2, 3, 4), NewVector(5, 6, 7, 8)
+	for _, s := range []Summation{NaiveSummation, CompensatedSummation} {
+		allocs := testing.AllocsPerRun(100, func() {
+			v1.DotProductUsing(v2, s)
+		})
+		if allocs != 0 {
+			t.Errorf("%v: expected the dot product not to allocate, but got %v allocations", s, allocs)
+		}
+	}
+}
+
+func TestMagnitudeOfLargeAndSmallVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Vector
+		expected float64
+	}{
+		{
+			name:     "squares overflow",
+			input:    NewVector(3e155, 4e155),
+			expected: 5e155,
+		},
+		{
+			name:     "squares overflow in high dimensions",
+			input:    NewVector(1e200, 1e200, 1e200, 1e200),
+			expected: 2e200,
+		},
+		{
+			name:     "squares underflow",
+			input:    NewVector(3e-170, 4e-170),
+			expected: 5e-170,
+		},
+		{
+			name:     "infinite element",
+			input:    NewVector(math.Inf(-1), 1),
+			expected: math.Inf(1),
+		},
+	}
+
+	for _, test := range tests {
+		actual := test.input.Magnitude()
+		if math.Abs(actual-test.expected) > test.expected*1e-15 && actual != test.expected {
+			t.Errorf("%s: for the magnitude of %v, expected %v, but got %v", test.name, test.input, test.expected, actual)
+		}
+		normalized := test.input.Normalize()
+		if !math.IsInf(test.expected, 0) && math.Abs(normalized.Magnitude()-1) > 1e-15 {
+			t.Errorf("%s: expected the normalized vector %v to have a magnitude of 1, but got %v", test.name, normalized, normalized.Magnitude())
+		}
+	}
+}
@@ -20,10 +20,14 @@ func NewSystem(equations ...Equation) System {
 // String writes out each equation in the system, delineated by commas and
 // surrounded by braces, e.g. { 1x₁ + 2x₂ + 3x₃ = 4, 5x₁ + 6x₂ + 7x₃ = 8 }
 func (s1 System) String() string {
+	return Formatter{}.System(s1)
+}
+
+func (s1 System) format(f Formatter, name func(index int) string) string {
 	buf := bytes.NewBufferString("{ ")
 
 	for i, e := range s1 {
-		buf.WriteString(e.String())
+		buf.WriteString(e.format(f, name))
 		if i < len(s1)-1 {
 			buf.WriteString(", ")
 		}
@@ -119,15 +123,20 @@ func (s1 System) FindFirstNonZeroCoefficients() (indices []int) {
 // TriangularForm organises the system by leading term.
 func (s1 System) TriangularForm() (System, error) {
 	// Copy the input to a new value.
-	op := s1
+	op := make(System, len(s1))
+	copy(op, s1)
 
 	if !s1.AllEquationsHaveSameNumberOfTerms() {
 		return op, errors.New("all equations in a system need to have the same number of terms")
 	}
+	if len(op) == 0 {
+		return op, nil
+	}
 
-	// Iterate through and elimate each term in order.
-	var termIndex int
-	for i := 0; i < len(op)-1; i++ {
+	// Iterate through and elimate each term in order. The current equation only moves on when a leading term
+	// is found, so that terms which have a zero coefficient in all of the remaining equations are skipped.
+	var i int
+	for termIndex := 0; termIndex < len(op[0].NormalVector) && i < len(op)-1; termIndex++ {
 		currentCoefficient := op[i].NormalVector[termIndex]
 		if tolerance.IsWithin(currentCoefficient, 0, DefaultTolerance) {
 			// Swap the current equation with the first one below it that has a non-zero coefficient for the term.
@@ -145,15 +154,17 @@ func (s1 System) TriangularForm() (System, error) {
 		// Apply the cancellation to all subsequent equations.
 		currentEquation := op[i]
 		currentCoefficient = currentEquation.NormalVector[termIndex]
-		if !tolerance.IsWithin(currentCoefficient, 0, DefaultTolerance) {
-			for j := i + 1; j < len(op); j++ {
-				nextEquation := op[j]
-				// No need to capture the error, the only possible error is mismatched or out-of-band terms
-				// This is tested for in AllEquationsHaveSameNumberOfTerms above.
-				op[j], _ = currentEquation.CancelTerm(nextEquation, termIndex)
-			}
+		if tolerance.IsWithin(currentCoefficient, 0, DefaultTolerance) {
+			// None of the remaining equations have this term, so move on to the next term.
+			continue
 		}
-		termIndex++
+		for j := i + 1; j < len(op); j++ {
+			nextEquation := op[j]
+			// No need to capture the error, the only possible error is mismatched or out-of-band terms
+			// This is tested for in AllEquationsHaveSameNumberOfTerms above.
+			op[j], _ = currentEquation.CancelTerm(nextEquation, termIndex)
+		}
+		i++
 	}
 
 	return op, nil
@@ -383,3 +394,22 @@ func convertPivotArrayToMap(integers []int) map[int]interface{} {
 	}
 	return rv
 }
+
+// SolutionSet finds every solution to the system. A single solution is returned as a Parameterization with a
+// basepoint and no direction vectors, while infinite solutions are returned as the parameterization of the RREF form
+// of the system. noSolution is true if the system is inconsistent.
+func (s1 System) SolutionSet() (p Parameterization, noSolution bool, err error) {
+	solution, noSolution, infiniteSolutions, err := s1.Solve()
+	if err != nil || noSolution {
+		return Parameterization{}, noSolution, err
+	}
+	if !infiniteSolutions {
+		return Parameterization{Basepoint: solution, DirectionVectors: []Vector{}}, false, nil
+	}
+	rref, _, err := s1.ComputeRREF()
+	if err != nil {
+		return Parameterization{}, false, err
+	}
+	p, err = rref.Parameterize()
+	return p, false, err
+}
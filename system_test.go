@@ -724,6 +724,17 @@ func TestSystemTriangularFormFunction(t *testing.T) {
 				NewEquation(NewVector(0, 1, 1), 1),
 				NewEquation(NewVector(0, 0, 1), 1)),
 		},
+		{
+			name: "terms which are zero in every remaining equation are skipped",
+			input: NewSystem(
+				NewEquation(NewVector(1, 0, 1, 0), 1),
+				NewEquation(NewVector(0, 0, 1, 1), 2),
+				NewEquation(NewVector(0, 0, 1, 2), 3)),
+			expected: NewSystem(
+				NewEquation(NewVector(1, 0, 1, 0), 1),
+				NewEquation(NewVector(0, 0, 1, 1), 2),
+				NewEquation(NewVector(0, 0, 0, 1), 1)),
+		},
 		{
 			name: "mismatched term count",
 			input: NewSystem(
@@ -784,6 +795,22 @@ func TestSystemTriangularFormFunction(t *testing.T) {
 	}
 }
 
+func TestSystemTriangularFormDoesNotModifyTheInput(t *testing.T) {
+	input := NewSystem(
+		NewEquation(NewVector(0, 1), 1),
+		NewEquation(NewVector(1, 1), 2))
+	expected := NewSystem(
+		NewEquation(NewVector(0, 1), 1),
+		NewEquation(NewVector(1, 1), 2))
+
+	if _, err := input.TriangularForm(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eq, _ := input.Eq(expected); !eq {
+		t.Errorf("expected the input to be unchanged as %v, but got %v", expected, input)
+	}
+}
+
 func TestSystemReducedRowEchelonFormFunction(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -1184,3 +1211,57 @@ func TestSystemParameterizationFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestSystemSolutionSetFunction(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              System
+		noSolution         bool
+		expectedBasepoint  Vector
+		expectedDirections int
+	}{
+		{
+			name: "single solution",
+			input: NewSystem(
+				NewEquation(NewVector(2, 0), 2),
+				NewEquation(NewVector(0, 2), 4)),
+			expectedBasepoint:  NewVector(1, 2),
+			expectedDirections: 0,
+		},
+		{
+			name: "infinite solutions",
+			input: NewSystem(
+				NewEquation(NewVector(1, 1, 1), 1),
+				NewEquation(NewVector(2, 2, 2), 2)),
+			expectedBasepoint:  NewVector(1, 0, 0),
+			expectedDirections: 2,
+		},
+		{
+			name: "no solution",
+			input: NewSystem(
+				NewEquation(NewVector(1, 1), 1),
+				NewEquation(NewVector(1, 1), 2)),
+			noSolution: true,
+		},
+	}
+
+	for _, test := range tests {
+		actual, noSolution, err := test.input.SolutionSet()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if noSolution != test.noSolution {
+			t.Errorf("%s: expected noSolution to be %v, but got %v", test.name, test.noSolution, noSolution)
+		}
+		if noSolution {
+			continue
+		}
+		if !actual.Basepoint.Eq(test.expectedBasepoint) {
+			t.Errorf("%s: expected basepoint %v, but got %v", test.name, test.expectedBasepoint, actual.Basepoint)
+		}
+		if len(actual.DirectionVectors) != test.expectedDirections {
+			t.Errorf("%s: expected %d direction vectors, but got %v", test.name, test.expectedDirections, actual.DirectionVectors)
+		}
+	}
+}
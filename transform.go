@@ -0,0 +1,355 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// Transform2D is an affine transformation of 2D space, stored as a 3x3 matrix in homogeneous coordinates, where
+// t[row][column]. A point (x, y) is transformed by multiplying the column vector (x, y, 1) by the matrix.
+type Transform2D [3][3]float64
+
+// Transform3D is an affine transformation of 3D space, stored as a 4x4 matrix in homogeneous coordinates, where
+// t[row][column]. A point (x, y, z) is transformed by multiplying the column vector (x, y, z, 1) by the matrix.
+type Transform3D [4][4]float64
+
+// IdentityTransform2D returns the 2D transform which leaves every point where it is.
+func IdentityTransform2D() Transform2D {
+	return Transform2D{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+// NewTranslation2D creates a 2D transform which moves every point by x and y.
+func NewTranslation2D(x, y float64) Transform2D {
+	t := IdentityTransform2D()
+	t[0][2], t[1][2] = x, y
+	return t
+}
+
+// NewScaling2D creates a 2D transform which scales every point away from the origin by x and y. Negative values
+// reflect in the corresponding axis.
+func NewScaling2D(x, y float64) Transform2D {
+	t := IdentityTransform2D()
+	t[0][0], t[1][1] = x, y
+	return t
+}
+
+// NewRotation2D creates a 2D transform which rotates every point anticlockwise around the origin.
+func NewRotation2D(angle Radian) Transform2D {
+	c, s := math.Cos(float64(angle)), math.Sin(float64(angle))
+	return Transform2D{
+		{c, -s, 0},
+		{s, c, 0},
+		{0, 0, 1},
+	}
+}
+
+// NewShear2D creates a 2D transform which shears x in proportion to y, and y in proportion to x, i.e.
+// (x, y) becomes (x + xy*y, y + yx*x).
+func NewShear2D(xy, yx float64) Transform2D {
+	t := IdentityTransform2D()
+	t[0][1], t[1][0] = xy, yx
+	return t
+}
+
+// NewReflection2D creates a 2D transform which reflects every point across the line through the origin in the
+// given direction.
+func NewReflection2D(direction Vector) (Transform2D, error) {
+	u, err := unitDirection(direction, 2)
+	if err != nil {
+		return Transform2D{}, err
+	}
+	t := IdentityTransform2D()
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			t[i][j] = 2*u[i]*u[j] - t[i][j]
+		}
+	}
+	return t, nil
+}
+
+// NewProjection2D creates a 2D transform which projects every point onto the line through the origin in the given
+// direction. Projections discard information, so they cannot be inverted.
+func NewProjection2D(direction Vector) (Transform2D, error) {
+	u, err := unitDirection(direction, 2)
+	if err != nil {
+		return Transform2D{}, err
+	}
+	t := IdentityTransform2D()
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			t[i][j] = u[i] * u[j]
+		}
+	}
+	return t, nil
+}
+
+func (t Transform2D) rows() [][]float64 {
+	return [][]float64{t[0][:], t[1][:], t[2][:]}
+}
+
+func newTransform2DFromRows(rows [][]float64) (t Transform2D) {
+	for i := range t {
+		copy(t[i][:], rows[i])
+	}
+	return t
+}
+
+func (t Transform2D) String() string {
+	return matrixString(t.rows())
+}
+
+// Eq compares an input transform against the current transform.
+func (t Transform2D) Eq(t2 Transform2D) bool {
+	return matrixEqWithinTolerance(t.rows(), t2.rows(), DefaultTolerance)
+}
+
+// Mul multiplies the current transform by t2. The result applies t2 first, followed by the current transform.
+func (t Transform2D) Mul(t2 Transform2D) Transform2D {
+	return newTransform2DFromRows(multiply(t.rows(), t2.rows()))
+}
+
+// Then returns a transform which applies the current transform, followed by next.
+func (t Transform2D) Then(next Transform2D) Transform2D {
+	return next.Mul(t)
+}
+
+// Inverse calculates the transform which undoes the current transform, or an error if the transform is not
+// invertible, e.g. because it is a projection or scales by zero.
+func (t Transform2D) Inverse() (Transform2D, error) {
+	inverse, ok := invert(t.rows())
+	if !ok {
+		return Transform2D{}, errors.New("the transform is not invertible")
+	}
+	return newTransform2DFromRows(inverse), nil
+}
+
+// Apply transforms the 2D point v.
+func (t Transform2D) Apply(v Vector) (Vector, error) {
+	return transformVector(t.rows(), v, 1)
+}
+
+// ApplyToDirection transforms the 2D direction v. Unlike a point, a direction is not affected by translation.
+func (t Transform2D) ApplyToDirection(v Vector) (Vector, error) {
+	return transformVector(t.rows(), v, 0)
+}
+
+// ApplyToEquation transforms the 2D line e, so that every point on the line is mapped to a point on the returned line.
+// The normal vector is multiplied by the inverse transpose of the transform, so the transform must be invertible.
+func (t Transform2D) ApplyToEquation(e Equation) (Equation, error) {
+	return transformEquation(t.rows(), e)
+}
+
+// IdentityTransform3D returns the 3D transform which leaves every point where it is.
+func IdentityTransform3D() Transform3D {
+	return Transform3D{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// NewTranslation3D creates a 3D transform which moves every point by x, y and z.
+func NewTranslation3D(x, y, z float64) Transform3D {
+	t := IdentityTransform3D()
+	t[0][3], t[1][3], t[2][3] = x, y, z
+	return t
+}
+
+// NewScaling3D creates a 3D transform which scales every point away from the origin by x, y and z. Negative values
+// reflect in the corresponding plane.
+func NewScaling3D(x, y, z float64) Transform3D {
+	t := IdentityTransform3D()
+	t[0][0], t[1][1], t[2][2] = x, y, z
+	return t
+}
+
+// NewRotation3D creates a 3D transform which rotates every point around the origin by the rotation represented by the
+// quaternion.
+func NewRotation3D(q Quaternion) Transform3D {
+	t := IdentityTransform3D()
+	for i, row := range q.RotationMatrix() {
+		copy(t[i][:], row[:])
+	}
+	return t
+}
+
+// NewShear3D creates a 3D transform which shears each coordinate in proportion to the other two, i.e. (x, y, z)
+// becomes (x + xy*y + xz*z, y + yx*x + yz*z, z + zx*x + zy*y).
+func NewShear3D(xy, xz, yx, yz, zx, zy float64) Transform3D {
+	t := IdentityTransform3D()
+	t[0][1], t[0][2] = xy, xz
+	t[1][0], t[1][2] = yx, yz
+	t[2][0], t[2][1] = zx, zy
+	return t
+}
+
+// NewReflection3D creates a 3D transform which reflects every point across the plane through the origin with the
+// given normal vector.
+func NewReflection3D(normal Vector) (Transform3D, error) {
+	n, err := unitDirection(normal, 3)
+	if err != nil {
+		return Transform3D{}, err
+	}
+	t := IdentityTransform3D()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] -= 2 * n[i] * n[j]
+		}
+	}
+	return t, nil
+}
+
+// NewProjection3D creates a 3D transform which projects every point onto the plane through the origin with the given
+// normal vector. Projections discard information, so they cannot be inverted.
+func NewProjection3D(normal Vector) (Transform3D, error) {
+	n, err := unitDirection(normal, 3)
+	if err != nil {
+		return Transform3D{}, err
+	}
+	t := IdentityTransform3D()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] -= n[i] * n[j]
+		}
+	}
+	return t, nil
+}
+
+func (t Transform3D) rows() [][]float64 {
+	return [][]float64{t[0][:], t[1][:], t[2][:], t[3][:]}
+}
+
+func newTransform3DFromRows(rows [][]float64) (t Transform3D) {
+	for i := range t {
+		copy(t[i][:], rows[i])
+	}
+	return t
+}
+
+func (t Transform3D) String() string {
+	return matrixString(t.rows())
+}
+
+// Eq compares an input transform against the current transform.
+func (t Transform3D) Eq(t2 Transform3D) bool {
+	return matrixEqWithinTolerance(t.rows(), t2.rows(), DefaultTolerance)
+}
+
+// Mul multiplies the current transform by t2. The result applies t2 first, followed by the current transform.
+func (t Transform3D) Mul(t2 Transform3D) Transform3D {
+	return newTransform3DFromRows(multiply(t.rows(), t2.rows()))
+}
+
+// Then returns a transform which applies the current transform, followed by next.
+func (t Transform3D) Then(next Transform3D) Transform3D {
+	return next.Mul(t)
+}
+
+// Inverse calculates the transform which undoes the current transform, or an error if the transform is not
+// invertible, e.g. because it is a projection or scales by zero.
+func (t Transform3D) Inverse() (Transform3D, error) {
+	inverse, ok := invert(t.rows())
+	if !ok {
+		return Transform3D{}, errors.New("the transform is not invertible")
+	}
+	return newTransform3DFromRows(inverse), nil
+}
+
+// Apply transforms the 3D point v.
+func (t Transform3D) Apply(v Vector) (Vector, error) {
+	return transformVector(t.rows(), v, 1)
+}
+
+// ApplyToDirection transforms the 3D direction v. Unlike a point, a direction is not affected by translation.
+func (t Transform3D) ApplyToDirection(v Vector) (Vector, error) {
+	return transformVector(t.rows(), v, 0)
+}
+
+// ApplyToEquation transforms the 3D plane e, so that every point on the plane is mapped to a point on the returned
+// plane. The normal vector is multiplied by the inverse transpose of the transform, so the transform must be
+// invertible.
+func (t Transform3D) ApplyToEquation(e Equation) (Equation, error) {
+	return transformEquation(t.rows(), e)
+}
+
+// unitDirection normalizes v, returning an error if it doesn't have the expected dimensions or is the zero vector.
+func unitDirection(v Vector, dimensions int) (Vector, error) {
+	if len(v) != dimensions {
+		return Vector{}, fmt.Errorf("the vector has %d dimensions but must have %d", len(v), dimensions)
+	}
+	if v.IsZeroVector() {
+		return Vector{}, errors.New("the vector cannot be the zero vector")
+	}
+	return v.Normalize(), nil
+}
+
+// transformVector multiplies the homogeneous coordinates (v, w) by the matrix and returns the result without the
+// homogeneous coordinate.
+func transformVector(m [][]float64, v Vector, w float64) (Vector, error) {
+	dimensions := len(m) - 1
+	if len(v) != dimensions {
+		return Vector{}, fmt.Errorf("the vector has %d dimensions but must have %d to be transformed", len(v), dimensions)
+	}
+	homogeneous := append(append(Vector{}, v...), w)
+	op := make(Vector, dimensions)
+	for i := 0; i < dimensions; i++ {
+		op[i], _ = Vector(m[i]).DotProduct(homogeneous)
+	}
+	return op, nil
+}
+
+// transformEquation transforms the hyperplane n·x = c. Writing the hyperplane in homogeneous coordinates as
+// p = (n, -c), so that p·(x, 1) = 0, the transformed hyperplane is p' = (M⁻¹)ᵀp.
+func transformEquation(m [][]float64, e Equation) (Equation, error) {
+	dimensions := len(m) - 1
+	if len(e.NormalVector) != dimensions {
+		return Equation{}, fmt.Errorf("the equation has %d dimensions but must have %d to be transformed", len(e.NormalVector), dimensions)
+	}
+	inverse, ok := invert(m)
+	if !ok {
+		return Equation{}, errors.New("the equation cannot be transformed because the transform is not invertible")
+	}
+	p := append(append(Vector{}, e.NormalVector...), -e.ConstantTerm)
+	op := make(Vector, len(p))
+	for j := range op {
+		for i := range p {
+			op[j] += inverse[i][j] * p[i]
+		}
+	}
+	return NewEquation(op[:dimensions], -op[dimensions]), nil
+}
+
+func matrixString(rows [][]float64) string {
+	buf := bytes.NewBufferString("[")
+	for i, row := range rows {
+		buf.WriteString(Vector(row).String())
+		if i < len(rows)-1 {
+			buf.WriteString(", ")
+		}
+	}
+	buf.WriteString("]")
+	return buf.String()
+}
+
+func matrixEqWithinTolerance(a, b [][]float64, t float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		for j := range a[i] {
+			if !tolerance.IsWithin(a[i][j], b[i][j], t) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,343 @@
+package linear
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTransform2DApplyFunction(t *testing.T) {
+	reflectInDiagonal, _ := NewReflection2D(NewVector(1, 1))
+	projectOntoX, _ := NewProjection2D(NewVector(3, 0))
+
+	tests := []struct {
+		name     string
+		t        Transform2D
+		v        Vector
+		expected Vector
+	}{
+		{
+			name:     "identity",
+			t:        IdentityTransform2D(),
+			v:        NewVector(3, 4),
+			expected: NewVector(3, 4),
+		},
+		{
+			name:     "translation",
+			t:        NewTranslation2D(1, -2),
+			v:        NewVector(3, 4),
+			expected: NewVector(4, 2),
+		},
+		{
+			name:     "scaling",
+			t:        NewScaling2D(2, -1),
+			v:        NewVector(3, 4),
+			expected: NewVector(6, -4),
+		},
+		{
+			name:     "rotation",
+			t:        NewRotation2D(NewRadian(90)),
+			v:        NewVector(3, 4),
+			expected: NewVector(-4, 3),
+		},
+		{
+			name:     "shear",
+			t:        NewShear2D(2, 0),
+			v:        NewVector(3, 4),
+			expected: NewVector(11, 4),
+		},
+		{
+			name:     "reflection in y = x",
+			t:        reflectInDiagonal,
+			v:        NewVector(3, 4),
+			expected: NewVector(4, 3),
+		},
+		{
+			name:     "projection onto the x axis",
+			t:        projectOntoX,
+			v:        NewVector(3, 4),
+			expected: NewVector(3, 0),
+		},
+		{
+			name:     "rotate then translate",
+			t:        NewRotation2D(NewRadian(90)).Then(NewTranslation2D(1, 1)),
+			v:        NewVector(1, 0),
+			expected: NewVector(1, 2),
+		},
+		{
+			name:     "translate then rotate",
+			t:        NewTranslation2D(1, 1).Then(NewRotation2D(NewRadian(90))),
+			v:        NewVector(1, 0),
+			expected: NewVector(-1, 2),
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.t.Apply(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestTransform2DDirectionsAreNotTranslated(t *testing.T) {
+	actual, err := NewTranslation2D(5, 5).ApplyToDirection(NewVector(1, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := NewVector(1, 0); !actual.Eq(expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestTransform2DInverseFunction(t *testing.T) {
+	transform := NewRotation2D(NewRadian(30)).Then(NewScaling2D(2, 3)).Then(NewTranslation2D(-1, 4)).Then(NewShear2D(0.5, 0))
+	inverse, err := transform.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := transform.Mul(inverse); !actual.Eq(IdentityTransform2D()) {
+		t.Errorf("expected the transform multiplied by its inverse to be the identity, but got %v", actual)
+	}
+
+	projection, _ := NewProjection2D(NewVector(1, 0))
+	if _, err := projection.Inverse(); err == nil || err.Error() != "the transform is not invertible" {
+		t.Errorf("expected an error inverting a projection, but got %v", err)
+	}
+
+	small := NewScaling2D(1e-11, 1e-11)
+	inverse, err = small.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error inverting a small uniform scaling: %v", err)
+	}
+	if expected := NewScaling2D(1e11, 1e11); !inverse.Eq(expected) {
+		t.Errorf("expected the inverse of a small uniform scaling to be %v, but got %v", expected, inverse)
+	}
+}
+
+func TestTransform2DApplyToEquationFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        Transform2D
+		e        Equation
+		expected Equation
+	}{
+		{
+			name:     "translate the line x = 1 to x = 3",
+			t:        NewTranslation2D(2, 5),
+			e:        NewEquation(NewVector(1, 0), 1),
+			expected: NewEquation(NewVector(1, 0), 3),
+		},
+		{
+			name:     "rotate the line x = 1 to y = 1",
+			t:        NewRotation2D(NewRadian(90)),
+			e:        NewEquation(NewVector(1, 0), 1),
+			expected: NewEquation(NewVector(0, 1), 1),
+		},
+		{
+			name:     "scale the line x + y = 1",
+			t:        NewScaling2D(2, 4),
+			e:        NewEquation(NewVector(1, 1), 1),
+			expected: NewEquation(NewVector(2, 1), 4),
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.t.ApplyToEquation(test.e)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if eq, _ := actual.Eq(test.expected); !eq {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+
+		// Points on the original line are transformed to points on the new line.
+		p, _ := test.e.NonZeroValuePoint()
+		tp, _ := test.t.Apply(p)
+		dp, _ := actual.NormalVector.DotProduct(tp)
+		if math.Abs(dp-actual.ConstantTerm) > DefaultTolerance {
+			t.Errorf("%s: expected the transformed point %v to be on the line %v", test.name, tp, actual)
+		}
+	}
+
+	projection, _ := NewProjection2D(NewVector(1, 0))
+	if _, err := projection.ApplyToEquation(NewEquation(NewVector(1, 0), 1)); err == nil {
+		t.Errorf("expected an error transforming a line with a projection")
+	}
+}
+
+func TestTransform2DErrors(t *testing.T) {
+	if _, err := IdentityTransform2D().Apply(NewVector(1, 2, 3)); err == nil || err.Error() != "the vector has 3 dimensions but must have 2 to be transformed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := IdentityTransform2D().ApplyToEquation(NewEquation(NewVector(1, 2, 3), 1)); err == nil || err.Error() != "the equation has 3 dimensions but must have 2 to be transformed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewReflection2D(NewVector(0, 0)); err == nil || err.Error() != "the vector cannot be the zero vector" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewProjection2D(NewVector(1, 0, 0)); err == nil || err.Error() != "the vector has 3 dimensions but must have 2" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTransform2DStringRepresentation(t *testing.T) {
+	actual := NewTranslation2D(1, 2).String()
+	expected := "[[1, 0, 1], [0, 1, 2], [0, 0, 1]]"
+	if actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestTransform3DApplyFunction(t *testing.T) {
+	q, _ := NewQuaternionFromAxisAngle(NewVector(0, 0, 1), NewRadian(90))
+	reflectInXY, _ := NewReflection3D(NewVector(0, 0, 2))
+	projectOntoXY, _ := NewProjection3D(NewVector(0, 0, 2))
+
+	tests := []struct {
+		name     string
+		t        Transform3D
+		v        Vector
+		expected Vector
+	}{
+		{
+			name:     "identity",
+			t:        IdentityTransform3D(),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(1, 2, 3),
+		},
+		{
+			name:     "translation",
+			t:        NewTranslation3D(1, -2, 3),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(2, 0, 6),
+		},
+		{
+			name:     "scaling",
+			t:        NewScaling3D(2, 3, 4),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(2, 6, 12),
+		},
+		{
+			name:     "rotation",
+			t:        NewRotation3D(q),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(-2, 1, 3),
+		},
+		{
+			name:     "shear",
+			t:        NewShear3D(1, 0, 0, 0, 0, 2),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(3, 2, 7),
+		},
+		{
+			name:     "reflection in the xy plane",
+			t:        reflectInXY,
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(1, 2, -3),
+		},
+		{
+			name:     "projection onto the xy plane",
+			t:        projectOntoXY,
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(1, 2, 0),
+		},
+		{
+			name:     "scale then translate",
+			t:        NewScaling3D(2, 2, 2).Then(NewTranslation3D(1, 1, 1)),
+			v:        NewVector(1, 2, 3),
+			expected: NewVector(3, 5, 7),
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.t.Apply(test.v)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestTransform3DInverseFunction(t *testing.T) {
+	q, _ := NewQuaternionFromAxisAngle(NewVector(1, 2, 3), NewRadian(40))
+	transform := NewRotation3D(q).Then(NewTranslation3D(1, 2, 3)).Then(NewScaling3D(1, 2, 0.5)).Then(NewShear3D(0.1, 0, 0, 0.2, 0, 0))
+	inverse, err := transform.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := inverse.Mul(transform); !actual.Eq(IdentityTransform3D()) {
+		t.Errorf("expected the inverse multiplied by the transform to be the identity, but got %v", actual)
+	}
+
+	if _, err := NewScaling3D(1, 0, 1).Inverse(); err == nil {
+		t.Errorf("expected an error inverting a transform which scales by zero")
+	}
+}
+
+func TestTransform3DApplyToEquationFunction(t *testing.T) {
+	q, _ := NewQuaternionFromAxisAngle(NewVector(0, 1, 0), NewRadian(90))
+
+	tests := []struct {
+		name     string
+		t        Transform3D
+		e        Equation
+		expected Equation
+	}{
+		{
+			name:     "translate the plane z = 1 to z = 4",
+			t:        NewTranslation3D(1, 2, 3),
+			e:        NewEquation(NewVector(0, 0, 1), 1),
+			expected: NewEquation(NewVector(0, 0, 1), 4),
+		},
+		{
+			name:     "rotate the plane z = 1 to x = 1",
+			t:        NewRotation3D(q),
+			e:        NewEquation(NewVector(0, 0, 1), 1),
+			expected: NewEquation(NewVector(1, 0, 0), 1),
+		},
+		{
+			name:     "shear keeps planes parallel to the shear direction",
+			t:        NewShear3D(1, 0, 0, 0, 0, 0),
+			e:        NewEquation(NewVector(0, 1, 0), 2),
+			expected: NewEquation(NewVector(0, 1, 0), 2),
+		},
+		{
+			name:     "reflect the plane x + y + z = 1",
+			t:        NewScaling3D(-1, -1, -1),
+			e:        NewEquation(NewVector(1, 1, 1), 1),
+			expected: NewEquation(NewVector(1, 1, 1), -1),
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.t.ApplyToEquation(test.e)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if eq, _ := actual.Eq(test.expected); !eq {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestTransform3DErrors(t *testing.T) {
+	_, err := IdentityTransform3D().ApplyToDirection(NewVector(1, 2))
+	if err == nil || !strings.HasPrefix(err.Error(), "the vector has 2 dimensions but must have 3") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	projection, _ := NewProjection3D(NewVector(1, 0, 0))
+	_, err = projection.ApplyToEquation(NewEquation(NewVector(1, 0, 0), 1))
+	if err == nil || err.Error() != "the equation cannot be transformed because the transform is not invertible" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
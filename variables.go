@@ -0,0 +1,457 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+// Variables is a schema which names each variable of a system of equations, e.g. price, qty and tax, where the name at
+// index i is the name of the variable multiplied by the coefficient at index i of each normal vector.
+type Variables []string
+
+// NewVariables creates a schema from the variable names. Names must be unique, must start with a letter or an
+// underscore, and can only contain letters, digits, subscript digits and underscores. To avoid confusion with numbers
+// in exponent form, e.g. 2e5, names cannot start with e or E followed by a digit.
+func NewVariables(names ...string) (Variables, error) {
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		if !isIdentifier(name) {
+			return Variables{}, fmt.Errorf("the variable name %q at index %d is not valid", name, i)
+		}
+		if seen[name] {
+			return Variables{}, fmt.Errorf("the variable name %q is used more than once", name)
+		}
+		seen[name] = true
+	}
+	return Variables(names), nil
+}
+
+// DefaultVariables creates a schema which uses the names x₁, x₂, ..., as used by the String functions of Equation,
+// System and Parameterization.
+func DefaultVariables(count int) Variables {
+	op := make(Variables, count)
+	for i := range op {
+		op[i] = defaultVariableName(i)
+	}
+	return op
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if i == 0 && !isIdentifierStart(r) {
+			return false
+		}
+		if !isIdentifierPart(r) {
+			return false
+		}
+	}
+	if len(s) > 1 && (s[0] == 'e' || s[0] == 'E') && s[1] >= '0' && s[1] <= '9' {
+		return false
+	}
+	return true
+}
+
+func isIdentifierStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r) || (r >= '₀' && r <= '₉')
+}
+
+// Index returns the index of the variable with the given name, or false if the schema doesn't contain it.
+func (vs Variables) Index(name string) (int, bool) {
+	for i, v := range vs {
+		if v == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// name returns the name of the variable at the index, falling back to the default name if the schema doesn't have
+// enough variables.
+func (vs Variables) name(index int) string {
+	if index >= len(vs) {
+		return defaultVariableName(index)
+	}
+	return vs[index]
+}
+
+func (vs Variables) check(dimensions int, of string) error {
+	if len(vs) != dimensions {
+		return fmt.Errorf("the schema has %d variables, but the %s has %d dimensions", len(vs), of, dimensions)
+	}
+	return nil
+}
+
+// FormatEquation writes out the equation using the variable names, e.g. 2price + 3qty = 5
+func (vs Variables) FormatEquation(e Equation) (string, error) {
+	if err := vs.check(len(e.NormalVector), "equation"); err != nil {
+		return "", err
+	}
+	return e.format(Formatter{}, vs.name), nil
+}
+
+// FormatSystem writes out the system using the variable names, e.g. { 1price + 1qty = 5, 1price - 1qty = 1 }
+func (vs Variables) FormatSystem(s System) (string, error) {
+	for _, e := range s {
+		if err := vs.check(len(e.NormalVector), "system"); err != nil {
+			return "", err
+		}
+	}
+	return s.format(Formatter{}, vs.name), nil
+}
+
+// FormatParameterization writes out the parameterization using the variable names. When a direction vector
+// corresponds to a free variable of the system, i.e. it is the only direction vector which changes that variable, and
+// does so with a coefficient of one from a basepoint value of zero, the free variable is named after the variable,
+// e.g. { price = 10 - qty, qty = qty }. Otherwise the free variables are named t, s, t₁, s₁, and so on.
+func (vs Variables) FormatParameterization(p Parameterization) (string, error) {
+	if err := vs.check(len(p.Basepoint), "parameterization"); err != nil {
+		return "", err
+	}
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	return p.format(Formatter{}, vs.name, vs.freeVariableNames(p)), nil
+}
+
+// freeVariableNames returns a function which names the free variables of the parameterization after the variables of
+// the schema, or the default names if they can't all be matched to a variable.
+func (vs Variables) freeVariableNames(p Parameterization) func(index int) string {
+	names := make([]string, len(p.DirectionVectors))
+	for directionIndex, d := range p.DirectionVectors {
+		for variableIndex, value := range d {
+			if tolerance.IsWithin(value, 1, DefaultTolerance) && tolerance.IsWithin(p.Basepoint[variableIndex], 0, DefaultTolerance) && onlyDirectionToChange(p.DirectionVectors, directionIndex, variableIndex) {
+				names[directionIndex] = vs[variableIndex]
+				break
+			}
+		}
+		if names[directionIndex] == "" {
+			return getFreeVariableName
+		}
+	}
+	return func(index int) string {
+		return names[index]
+	}
+}
+
+func onlyDirectionToChange(directions []Vector, directionIndex int, variableIndex int) bool {
+	for i, d := range directions {
+		if i != directionIndex && !tolerance.IsWithin(d[variableIndex], 0, DefaultTolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Values converts a vector, e.g. a solution to a system, into a map of variable names to values.
+func (vs Variables) Values(v Vector) (map[string]float64, error) {
+	if err := vs.check(len(v), "vector"); err != nil {
+		return nil, err
+	}
+	op := make(map[string]float64, len(v))
+	for i, name := range vs {
+		op[name] = v[i]
+	}
+	return op, nil
+}
+
+// Vector converts a map of variable names to values into a vector. Every variable in the schema must have a value.
+func (vs Variables) Vector(values map[string]float64) (Vector, error) {
+	for name := range values {
+		if _, ok := vs.Index(name); !ok {
+			return Vector{}, fmt.Errorf("unknown variable %q", name)
+		}
+	}
+	op := make(Vector, len(vs))
+	for i, name := range vs {
+		value, ok := values[name]
+		if !ok {
+			return Vector{}, fmt.Errorf("missing a value for the variable %q", name)
+		}
+		op[i] = value
+	}
+	return op, nil
+}
+
+// DetectVariables finds the names of the variables used in a system of equations, in the order in which they first
+// appear, so that text such as "2x + 3y = 5, x - y = 1" can be parsed without declaring a schema first. If every name
+// is a default name, i.e. x₁, x₂, and so on, the default schema is returned instead, so that each variable keeps its
+// position even if some aren't used, e.g. x₂ in "x₁ + x₃ = 1".
+func DetectVariables(s string) Variables {
+	op := Variables{}
+	seen := map[string]bool{}
+	l := &lexer{input: s}
+	for !l.done() {
+		if _, ok := l.number(); ok {
+			continue
+		}
+		if !isIdentifierStart(l.peek()) {
+			l.next()
+			continue
+		}
+		name := l.identifier()
+		if !seen[name] {
+			seen[name] = true
+			op = append(op, name)
+		}
+	}
+	defaults := DefaultVariables(defaultVariableCount(s))
+	for _, name := range op {
+		if _, ok := defaults.Index(name); !ok {
+			return op
+		}
+	}
+	return defaults
+}
+
+// ParseEquation parses a linear equation which uses the variable names, e.g. "2price + 0.5*qty - tax = 10". Each side
+// of the equation can contain any number of terms, and each term is either a number, a variable name, or a number
+// followed by a variable name, optionally separated by *. A variable can appear more than once, in which case its
+// coefficients are added together. Variables which are not used have a coefficient of zero. Positions in error
+// messages count characters rather than bytes, starting from one, so that they are correct after names such as x₁.
+func (vs Variables) ParseEquation(s string) (Equation, error) {
+	sides := strings.Split(s, "=")
+	if len(sides) != 2 {
+		return Equation{}, fmt.Errorf("the equation %q must contain a single '='", s)
+	}
+	leftCoefficients, leftConstant, err := vs.parseExpression(sides[0], 0)
+	if err != nil {
+		return Equation{}, err
+	}
+	rightCoefficients, rightConstant, err := vs.parseExpression(sides[1], utf8.RuneCountInString(sides[0])+1)
+	if err != nil {
+		return Equation{}, err
+	}
+	// Move the variables to the left hand side and the constants to the right hand side.
+	normal, _ := leftCoefficients.Sub(rightCoefficients)
+	return NewEquation(normal, rightConstant-leftConstant), nil
+}
+
+// ParseSystem parses a system of equations which are separated by commas, semicolons or new lines, and optionally
+// surrounded by braces, as written by FormatSystem.
+func (vs Variables) ParseSystem(s string) (System, error) {
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+		trimmed = trimmed[1 : len(trimmed)-1]
+	}
+	op := System{}
+	for _, part := range strings.FieldsFunc(trimmed, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		e, err := vs.ParseEquation(part)
+		if err != nil {
+			return System{}, err
+		}
+		op = append(op, e)
+	}
+	if len(op) == 0 {
+		return System{}, errors.New("the system does not contain any equations")
+	}
+	return op, nil
+}
+
+// parseExpression parses a sum of terms, returning the coefficient of each variable and the sum of the constants.
+// offset is the number of characters before the expression in the equation, and is used in error messages.
+func (vs Variables) parseExpression(s string, offset int) (coefficients Vector, constant float64, err error) {
+	coefficients = make(Vector, len(vs))
+	l := &lexer{input: s}
+	l.skipSpace()
+	if l.done() {
+		return coefficients, 0, fmt.Errorf("expected a term at position %d", offset+l.column(l.pos))
+	}
+	first := true
+	for !l.done() {
+		sign := 1.0
+		// Terms after the first must be preceded by an operator.
+		if r := l.peek(); r == '+' || r == '-' {
+			if r == '-' {
+				sign = -1
+			}
+			l.next()
+			l.skipSpace()
+		} else if !first {
+			return coefficients, 0, fmt.Errorf("expected '+' or '-' at position %d, but found %q", offset+l.column(l.pos), r)
+		}
+		first = false
+
+		start := l.pos
+		value, hasNumber := l.number()
+		if !hasNumber {
+			value = 1
+		}
+		l.skipSpace()
+		if hasNumber && l.peek() == '*' {
+			l.next()
+			l.skipSpace()
+			if !isIdentifierStart(l.peek()) {
+				return coefficients, 0, fmt.Errorf("expected a variable name after '*' at position %d", offset+l.column(l.pos))
+			}
+		}
+		if isIdentifierStart(l.peek()) {
+			namePosition := l.pos
+			name := l.identifier()
+			index, ok := vs.Index(name)
+			if !ok {
+				return coefficients, 0, fmt.Errorf("unknown variable %q at position %d", name, offset+l.column(namePosition))
+			}
+			coefficients[index] += sign * value
+		} else if hasNumber {
+			constant += sign * value
+		} else {
+			if l.done() {
+				return coefficients, 0, fmt.Errorf("expected a term at position %d", offset+l.column(start))
+			}
+			return coefficients, 0, fmt.Errorf("unexpected %q at position %d", l.peek(), offset+l.column(l.pos))
+		}
+		l.skipSpace()
+	}
+	return coefficients, constant, nil
+}
+
+// lexer reads numbers and identifiers from a string.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) done() bool {
+	return l.pos >= len(l.input)
+}
+
+func (l *lexer) peek() rune {
+	if l.done() {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return r
+}
+
+func (l *lexer) next() rune {
+	r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+	l.pos += size
+	return r
+}
+
+// column returns the position of the character at the byte offset pos, counting from one.
+func (l *lexer) column(pos int) int {
+	return utf8.RuneCountInString(l.input[:pos]) + 1
+}
+
+func (l *lexer) skipSpace() {
+	for !l.done() && unicode.IsSpace(l.peek()) {
+		l.next()
+	}
+}
+
+func (l *lexer) identifier() string {
+	start := l.pos
+	for !l.done() && isIdentifierPart(l.peek()) {
+		l.next()
+	}
+	return l.input[start:l.pos]
+}
+
+// number reads an unsigned decimal number, with an optional exponent, e.g. 12, 0.5, .5 or 1.5e-3.
+func (l *lexer) number() (float64, bool) {
+	start := l.pos
+	digits := 0
+	for !l.done() && isDigit(l.input[l.pos]) {
+		l.pos++
+		digits++
+	}
+	if !l.done() && l.input[l.pos] == '.' {
+		l.pos++
+		for !l.done() && isDigit(l.input[l.pos]) {
+			l.pos++
+			digits++
+		}
+	}
+	if digits == 0 {
+		l.pos = start
+		return 0, false
+	}
+	// Only read an exponent if it is followed by digits, so that 2e is read as 2 multiplied by the variable e.
+	if !l.done() && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		end := l.pos + 1
+		if end < len(l.input) && (l.input[end] == '+' || l.input[end] == '-') {
+			end++
+		}
+		if end < len(l.input) && isDigit(l.input[end]) {
+			l.pos = end
+			for !l.done() && isDigit(l.input[l.pos]) {
+				l.pos++
+			}
+		}
+	}
+	value, err := strconv.ParseFloat(l.input[start:l.pos], 64)
+	if err != nil {
+		// Only out of range values can fail to parse, because the syntax is checked above.
+		l.pos = start
+		return 0, false
+	}
+	return value, true
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// NamedSystem is a system of equations where each variable has a name.
+type NamedSystem struct {
+	Variables Variables
+	System    System
+}
+
+// NewNamedSystem creates a system of equations which uses the variable names. Every equation must have one coefficient
+// for each variable.
+func NewNamedSystem(variables Variables, equations ...Equation) (NamedSystem, error) {
+	for i, e := range equations {
+		if len(e.NormalVector) != len(variables) {
+			return NamedSystem{}, fmt.Errorf("the schema has %d variables, but the equation at index %d has %d dimensions", len(variables), i, len(e.NormalVector))
+		}
+	}
+	return NamedSystem{Variables: variables, System: NewSystem(equations...)}, nil
+}
+
+// ParseNamedSystem parses a system of equations which use the variable names, see Variables.ParseSystem.
+func ParseNamedSystem(variables Variables, s string) (NamedSystem, error) {
+	system, err := variables.ParseSystem(s)
+	if err != nil {
+		return NamedSystem{}, err
+	}
+	return NamedSystem{Variables: variables, System: system}, nil
+}
+
+// String writes out each equation in the system using the variable names.
+func (ns NamedSystem) String() string {
+	return ns.System.format(Formatter{}, ns.Variables.name)
+}
+
+// Solve solves the system, returning the value of each variable if there is a single solution.
+func (ns NamedSystem) Solve() (solution map[string]float64, noSolution bool, infiniteSolutions bool, err error) {
+	v, noSolution, infiniteSolutions, err := ns.System.Solve()
+	if err != nil || noSolution || infiniteSolutions {
+		return nil, noSolution, infiniteSolutions, err
+	}
+	solution, err = ns.Variables.Values(v)
+	return solution, false, false, err
+}
+
+// SolutionSet finds every solution to the system, see System.SolutionSet. Use Variables.FormatParameterization to
+// write out the result using the variable names.
+func (ns NamedSystem) SolutionSet() (p Parameterization, noSolution bool, err error) {
+	return ns.System.SolutionSet()
+}
@@ -0,0 +1,309 @@
+package linear
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewVariablesFunction(t *testing.T) {
+	tests := []struct {
+		names                []string
+		expectedErrorMessage string
+	}{
+		{names: []string{"price", "qty", "tax"}},
+		{names: []string{"x₁", "x₂", "_total", "größe"}},
+		{names: []string{"price", "price"}, expectedErrorMessage: "the variable name \"price\" is used more than once"},
+		{names: []string{""}, expectedErrorMessage: "the variable name \"\" at index 0 is not valid"},
+		{names: []string{"a", "2b"}, expectedErrorMessage: "the variable name \"2b\" at index 1 is not valid"},
+		{names: []string{"unit price"}, expectedErrorMessage: "the variable name \"unit price\" at index 0 is not valid"},
+		{names: []string{"e1"}, expectedErrorMessage: "the variable name \"e1\" at index 0 is not valid"},
+	}
+
+	for _, test := range tests {
+		_, err := NewVariables(test.names...)
+		var actual string
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expectedErrorMessage {
+			t.Errorf("for %v, expected error '%v', but got '%v'", test.names, test.expectedErrorMessage, actual)
+		}
+	}
+}
+
+func TestVariablesFormatFunctions(t *testing.T) {
+	vs, _ := NewVariables("price", "qty", "tax")
+
+	e, err := vs.FormatEquation(NewEquation(NewVector(2, -1, 0.5), 10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "2price - 1qty + 0.5tax = 10"; e != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, e)
+	}
+
+	s, err := vs.FormatSystem(NewSystem(NewEquation(NewVector(1, 1, 0), 5), NewEquation(NewVector(0, 0, 1), 2)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "{ 1price + 1qty + 0tax = 5, 0price + 0qty + 1tax = 2 }"; s != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, s)
+	}
+
+	if _, err := vs.FormatEquation(NewEquation(NewVector(1, 2), 3)); err == nil || err.Error() != "the schema has 3 variables, but the equation has 2 dimensions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVariablesFormatParameterizationFunction(t *testing.T) {
+	vs, _ := NewVariables("price", "qty", "tax")
+
+	tests := []struct {
+		name     string
+		p        Parameterization
+		expected string
+	}{
+		{
+			name: "free variables are named after the variables",
+			p: Parameterization{
+				Basepoint:        NewVector(10, 0, 2),
+				DirectionVectors: []Vector{NewVector(-1, 1, 0)},
+			},
+			expected: "{ price = 10 - qty, qty = qty, tax = 2 }",
+		},
+		{
+			name: "directions which don't match a variable use the default names",
+			p: Parameterization{
+				Basepoint:        NewVector(10, 0, 2),
+				DirectionVectors: []Vector{NewVector(-1, 2, 0)},
+			},
+			expected: "{ price = 10 - t, qty = 2t, tax = 2 }",
+		},
+		{
+			name: "two free variables",
+			p: Parameterization{
+				Basepoint:        NewVector(0, 0, 3),
+				DirectionVectors: []Vector{NewVector(1, 0, -1), NewVector(0, 1, -2)},
+			},
+			expected: "{ price = price, qty = qty, tax = 3 - price - 2qty }",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := vs.FormatParameterization(test.p)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected '%v', but got '%v'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestVariablesParseEquationFunction(t *testing.T) {
+	vs, _ := NewVariables("price", "qty", "tax")
+
+	tests := []struct {
+		input                string
+		expected             Equation
+		expectedErrorMessage string
+	}{
+		{
+			input:    "2price - 1qty + 0.5tax = 10",
+			expected: NewEquation(NewVector(2, -1, 0.5), 10),
+		},
+		{
+			input:    "price + 0.5 * qty = tax",
+			expected: NewEquation(NewVector(1, 0.5, -1), 0),
+		},
+		{
+			input:    "-qty + 3 = 1 - price",
+			expected: NewEquation(NewVector(1, -1, 0), -2),
+		},
+		{
+			input:    "price + price + 1.5e2 tax = 2E-1",
+			expected: NewEquation(NewVector(2, 0, 150), 0.2),
+		},
+		{
+			input:                "price + discount = 3",
+			expectedErrorMessage: "unknown variable \"discount\" at position 9",
+		},
+		{
+			input:                "price + qty",
+			expectedErrorMessage: "the equation \"price + qty\" must contain a single '='",
+		},
+		{
+			input:                "price qty = 3",
+			expectedErrorMessage: "expected '+' or '-' at position 7, but found 'q'",
+		},
+		{
+			input:                "price + = 3",
+			expectedErrorMessage: "expected a term at position 9",
+		},
+		{
+			input:                "price + 2* = 3",
+			expectedErrorMessage: "expected a variable name after '*' at position 12",
+		},
+		{
+			input:                " = 3",
+			expectedErrorMessage: "expected a term at position 2",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := vs.ParseEquation(test.input)
+		if err != nil {
+			if test.expectedErrorMessage != err.Error() {
+				t.Errorf("for '%v', expected error '%v', but got '%v'", test.input, test.expectedErrorMessage, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("for '%v', expected error '%v', but got %v", test.input, test.expectedErrorMessage, actual)
+			continue
+		}
+		if !actual.NormalVector.Eq(test.expected.NormalVector) || actual.ConstantTerm != test.expected.ConstantTerm {
+			t.Errorf("for '%v', expected %v, but got %v", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestVariablesParseEquationErrorPositionsCountCharacters(t *testing.T) {
+	greek, _ := NewVariables("α", "β")
+
+	tests := []struct {
+		variables            Variables
+		input                string
+		expectedErrorMessage string
+	}{
+		{
+			variables:            DefaultVariables(2),
+			input:                "x₁ x₂ = 3",
+			expectedErrorMessage: "expected '+' or '-' at position 4, but found 'x'",
+		},
+		{
+			variables:            DefaultVariables(2),
+			input:                "x₁ + y = 1",
+			expectedErrorMessage: "unknown variable \"y\" at position 6",
+		},
+		{
+			variables:            DefaultVariables(2),
+			input:                "x₁ = x₂ + z",
+			expectedErrorMessage: "unknown variable \"z\" at position 11",
+		},
+		{
+			variables:            greek,
+			input:                "2α + 3β = γ",
+			expectedErrorMessage: "unknown variable \"γ\" at position 11",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := test.variables.ParseEquation(test.input)
+		if err == nil || err.Error() != test.expectedErrorMessage {
+			t.Errorf("for '%v', expected error '%v', but got '%v'", test.input, test.expectedErrorMessage, err)
+		}
+	}
+}
+
+func TestVariablesParseRoundTrip(t *testing.T) {
+	s := NewSystem(
+		NewEquation(NewVector(5.862, 1.178, -10.366), -8.15),
+		NewEquation(NewVector(-2.931, -0.589, 5.183), -4.075),
+	)
+
+	// The default names match the output of String.
+	parsed, err := DefaultVariables(3).ParseSystem(s.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eq, _ := parsed.Eq(s); !eq {
+		t.Errorf("expected %v, but got %v", s, parsed)
+	}
+
+	vs, _ := NewVariables("price", "qty", "tax")
+	formatted, _ := vs.FormatSystem(s)
+	parsed, err = vs.ParseSystem(formatted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eq, _ := parsed.Eq(s); !eq {
+		t.Errorf("expected %v, but got %v", s, parsed)
+	}
+}
+
+func TestDetectVariablesFunction(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Variables
+	}{
+		{
+			input:    "{ 2x + 3y = 5, x - y = 1 }",
+			expected: Variables{"x", "y"},
+		},
+		{
+			input:    "2e3price + 1.5e-2*qty = tax",
+			expected: Variables{"price", "qty", "tax"},
+		},
+		{
+			input:    "1x₃ + 2x₁ = 4",
+			expected: Variables{"x₁", "x₂", "x₃"},
+		},
+		{
+			input:    "1 = 2",
+			expected: Variables{},
+		},
+	}
+
+	for _, test := range tests {
+		actual := DetectVariables(test.input)
+		if strings.Join(actual, ",") != strings.Join(test.expected, ",") || len(actual) != len(test.expected) {
+			t.Errorf("for '%v', expected %v, but got %v", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestNamedSystemSolveFunction(t *testing.T) {
+	vs, _ := NewVariables("price", "qty", "tax")
+	ns, err := ParseNamedSystem(vs, "price + qty + tax = 12; price - qty = 2\ntax = 2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "{ 1price + 1qty + 1tax = 12, 1price - 1qty + 0tax = 2, 0price + 0qty + 1tax = 2 }"; ns.String() != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, ns.String())
+	}
+
+	solution, noSolution, infiniteSolutions, err := ns.Solve()
+	if err != nil || noSolution || infiniteSolutions {
+		t.Fatalf("expected a single solution, but got noSolution %v, infiniteSolutions %v and error %v", noSolution, infiniteSolutions, err)
+	}
+	expected := map[string]float64{"price": 6, "qty": 4, "tax": 2}
+	for name, value := range expected {
+		if actual := solution[name]; !NewVector(actual).Eq(NewVector(value)) {
+			t.Errorf("expected %s to be %v, but got %v", name, value, actual)
+		}
+	}
+
+	v, err := vs.Vector(solution)
+	if err != nil || !v.Eq(NewVector(6, 4, 2)) {
+		t.Errorf("expected [6, 4, 2], but got %v and error %v", v, err)
+	}
+	if _, err := vs.Vector(map[string]float64{"price": 1}); err == nil || err.Error() != "missing a value for the variable \"qty\"" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	underdetermined, _ := ParseNamedSystem(vs, "price + qty = 10, tax = 2")
+	p, noSolution, err := underdetermined.SolutionSet()
+	if err != nil || noSolution {
+		t.Fatalf("expected a solution, but got noSolution %v and error %v", noSolution, err)
+	}
+	formatted, _ := vs.FormatParameterization(p)
+	if expected := "{ price = 10 - qty, qty = qty, tax = 2 }"; formatted != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, formatted)
+	}
+
+	if _, err := NewNamedSystem(vs, NewEquation(NewVector(1, 2), 3)); err == nil {
+		t.Errorf("expected an error creating a named system with the wrong number of variables")
+	}
+}
@@ -18,12 +18,13 @@ func NewVector(values ...float64) Vector {
 }
 
 func (v1 Vector) String() string {
-	if len(v1) == 1 {
-		return fmt.Sprintf("[%v]", v1[0])
-	}
+	return Formatter{}.Vector(v1)
+}
+
+func (v1 Vector) format(f Formatter) string {
 	buf := bytes.NewBufferString("[")
 	for i, p := range v1 {
-		buf.WriteString(fmt.Sprintf("%v", p))
+		buf.WriteString(f.Number(p))
 
 		if i < len(v1)-1 {
 			buf.WriteString(", ")
@@ -96,14 +97,38 @@ func (v1 Vector) Scale(scalar float64) Vector {
 	return Vector(op)
 }
 
-// Magnitude calculates the magnitude of the vector by calculating the square root of
-// the sum of each element squared.
+// Magnitude calculates the magnitude (Euclidean norm) of the vector by calculating the square root of
+// the sum of each element squared. If the sum of squares overflows or underflows, the elements are scaled
+// by the largest element before squaring, so that vectors with very large or very small elements still
+// have a finite, non-zero magnitude.
 func (v1 Vector) Magnitude() float64 {
 	var sumOfSquares float64
 	for _, v := range v1 {
 		sumOfSquares += (v * v)
 	}
-	return math.Sqrt(sumOfSquares)
+	if math.IsNaN(sumOfSquares) || (!math.IsInf(sumOfSquares, 0) && sumOfSquares >= minSafeSumOfSquares) {
+		return math.Sqrt(sumOfSquares)
+	}
+	return v1.scaledMagnitude()
+}
+
+// minSafeSumOfSquares is the smallest sum of squares which is calculated without underflow. Below this, the
+// squares of small elements may have been rounded to zero.
+const minSafeSumOfSquares = 0x1p-900
+
+// scaledMagnitude calculates the magnitude in the same way as hypot, by dividing each element by the largest
+// element, so that the squares cannot overflow.
+func (v1 Vector) scaledMagnitude() float64 {
+	scale := v1.InfinityNorm()
+	if scale == 0 || math.IsInf(scale, 1) || math.IsNaN(scale) {
+		return scale
+	}
+	var sumOfSquares float64
+	for _, v := range v1 {
+		r := v / scale
+		sumOfSquares += r * r
+	}
+	return scale * math.Sqrt(sumOfSquares)
 }
 
 // Normalize normalizes the magnitude of a vector to 1 and returns a new vector.
@@ -126,16 +151,43 @@ func (v1 Vector) IsZeroVector() bool {
 }
 
 // DotProduct calculates the dot product of the current vector and the input vector, or an error if the dimensions
-// of the vectors do not match.
+// of the vectors do not match. The products are added together using compensated summation.
 func (v1 Vector) DotProduct(v2 Vector) (float64, error) {
+	return v1.DotProductUsing(v2, CompensatedSummation)
+}
+
+// DotProductUsing calculates the dot product of the current vector and the input vector, adding the products together
+// with the given summation algorithm. When compensated summation is used, the rounding error of each multiplication
+// is also included, so the result is as accurate as if it had been calculated with twice the working precision.
+func (v1 Vector) DotProductUsing(v2 Vector, s Summation) (float64, error) {
 	var rv float64
 	if len(v1) != len(v2) {
 		return rv, fmt.Errorf("cannot calculate the dot product of the vectors because they have different dimensions (%d and %d)", len(v1), len(v2))
 	}
+	switch s {
+	case NaiveSummation:
+		for i := 0; i < len(v1); i++ {
+			rv += v1[i] * v2[i]
+		}
+		return rv, nil
+	case PairwiseSummation:
+		// Pairwise summation needs all of the products before it can split them in half.
+		products := make([]float64, len(v1))
+		for i := 0; i < len(v1); i++ {
+			products[i] = v1[i] * v2[i]
+		}
+		return pairwiseSum(products), nil
+	}
+	// Add each product as it is calculated, so that the default dot product doesn't allocate.
+	var n neumaier
 	for i := 0; i < len(v1); i++ {
-		rv += v1[i] * v2[i]
+		p := v1[i] * v2[i]
+		n.add(p)
+		if !math.IsInf(p, 0) {
+			n.compensation += math.FMA(v1[i], v2[i], -p)
+		}
 	}
-	return rv, nil
+	return n.result(), nil
 }
 
 // AngleBetween returns the angle (in radians) between the current vector and v2, or an error if the dimensions of
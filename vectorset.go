@@ -0,0 +1,191 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// VectorSet is a set of vectors which all have the same dimensions, e.g. the vectors which span a space, or the
+// vectors which form a basis.
+type VectorSet []Vector
+
+// NewVectorSet creates a new set of vectors.
+func NewVectorSet(vectors ...Vector) VectorSet {
+	return VectorSet(vectors)
+}
+
+// String writes out each vector in the set, delineated by commas and surrounded by braces, e.g. { [1, 0], [0, 1] }
+func (vs VectorSet) String() string {
+	buf := bytes.NewBufferString("{ ")
+
+	for i, v := range vs {
+		buf.WriteString(v.String())
+		if i < len(vs)-1 {
+			buf.WriteString(", ")
+		}
+	}
+
+	buf.WriteString(" }")
+	return buf.String()
+}
+
+// AllVectorsHaveSameDimensions returns true when all vectors in the set have the same number of dimensions.
+func (vs VectorSet) AllVectorsHaveSameDimensions() bool {
+	for _, v := range vs {
+		if len(v) != len(vs[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// system creates a system of equations where each vector in the set is a column of coefficients, and the constant
+// terms are the elements of the target vector. Solving the system finds the coefficients which combine the vectors
+// in the set to make the target.
+func (vs VectorSet) system(target Vector) (System, error) {
+	if !vs.AllVectorsHaveSameDimensions() {
+		return System{}, errors.New("all vectors in the set need to have the same number of dimensions")
+	}
+	if len(vs) > 0 && len(target) != len(vs[0]) {
+		return System{}, fmt.Errorf("the vector has %d dimensions, but the vectors in the set have %d dimensions", len(target), len(vs[0]))
+	}
+	s := make(System, len(target))
+	for i := range target {
+		coefficients := make([]float64, len(vs))
+		for j, v := range vs {
+			coefficients[j] = v[i]
+		}
+		s[i] = NewEquation(Vector(coefficients), target[i])
+	}
+	return s, nil
+}
+
+// pivots returns the indices of the vectors in the set which are not a linear combination of the vectors before them.
+func (vs VectorSet) pivots() ([]int, error) {
+	if len(vs) == 0 {
+		return []int{}, nil
+	}
+	s, err := vs.system(make(Vector, len(vs[0])))
+	if err != nil {
+		return nil, err
+	}
+	rref, _, err := s.ComputeRREF()
+	if err != nil {
+		return nil, err
+	}
+	pivots := []int{}
+	for _, index := range rref.FindFirstNonZeroCoefficients() {
+		if index >= 0 {
+			pivots = append(pivots, index)
+		}
+	}
+	return pivots, nil
+}
+
+// Rank returns the number of linearly independent vectors in the set, which is the dimension of the space that
+// they span.
+func (vs VectorSet) Rank() (int, error) {
+	pivots, err := vs.pivots()
+	return len(pivots), err
+}
+
+// IsLinearlyIndependent determines whether none of the vectors in the set can be made from a linear combination of the
+// others. The empty set is linearly independent, and any set containing the zero vector is not.
+func (vs VectorSet) IsLinearlyIndependent() (bool, error) {
+	rank, err := vs.Rank()
+	if err != nil {
+		return false, err
+	}
+	return rank == len(vs), nil
+}
+
+// SpanContains determines whether the vector v is in the span of the set, i.e. whether it is a linear combination of
+// the vectors in the set.
+func (vs VectorSet) SpanContains(v Vector) (bool, error) {
+	if len(vs) == 0 {
+		return v.IsZeroVector(), nil
+	}
+	s, err := vs.system(v)
+	if err != nil {
+		return false, err
+	}
+	_, noSolution, _, err := s.Solve()
+	if err != nil {
+		return false, err
+	}
+	return !noSolution, nil
+}
+
+// Basis returns a basis of the span of the set, made up of the vectors in the set which are not a linear combination
+// of the vectors before them.
+func (vs VectorSet) Basis() (VectorSet, error) {
+	pivots, err := vs.pivots()
+	if err != nil {
+		return VectorSet{}, err
+	}
+	op := make(VectorSet, len(pivots))
+	for i, index := range pivots {
+		op[i] = vs[index]
+	}
+	return op, nil
+}
+
+// LinearCombination multiplies each vector in the set by the coefficient with the same index and adds the results
+// together.
+func (vs VectorSet) LinearCombination(coefficients Vector) (Vector, error) {
+	if len(coefficients) != len(vs) {
+		return Vector{}, fmt.Errorf("cannot create a linear combination of %d vectors from %d coefficients", len(vs), len(coefficients))
+	}
+	if len(vs) == 0 {
+		return Vector{}, nil
+	}
+	if !vs.AllVectorsHaveSameDimensions() {
+		return Vector{}, errors.New("all vectors in the set need to have the same number of dimensions")
+	}
+	op := make(Vector, len(vs[0]))
+	for i, v := range vs {
+		// No need to check the error, the dimensions are checked above.
+		op, _ = op.Add(v.Scale(coefficients[i]))
+	}
+	return op, nil
+}
+
+// Coordinates expresses v as coordinates in the basis, i.e. the coefficients which are applied to each vector in the
+// basis to make v. The set must be linearly independent, and v must be in its span.
+func (vs VectorSet) Coordinates(v Vector) (Vector, error) {
+	if len(vs) == 0 {
+		return Vector{}, errors.New("the basis must contain at least one vector")
+	}
+	s, err := vs.system(v)
+	if err != nil {
+		return Vector{}, err
+	}
+	solution, noSolution, infiniteSolutions, err := s.Solve()
+	if err != nil {
+		return Vector{}, err
+	}
+	if infiniteSolutions {
+		return Vector{}, errors.New("the vectors in the basis are not linearly independent")
+	}
+	if noSolution {
+		return Vector{}, fmt.Errorf("the vector %v is not in the span of the basis", v)
+	}
+	return solution, nil
+}
+
+// ChangeBasis converts coordinates in the current basis to coordinates in the target basis. Both bases must be
+// linearly independent, and the vector described by the coordinates must be in the span of the target basis.
+func (vs VectorSet) ChangeBasis(coordinates Vector, to VectorSet) (Vector, error) {
+	if ok, err := vs.IsLinearlyIndependent(); !ok || err != nil {
+		if err != nil {
+			return Vector{}, err
+		}
+		return Vector{}, errors.New("the vectors in the basis are not linearly independent")
+	}
+	v, err := vs.LinearCombination(coordinates)
+	if err != nil {
+		return Vector{}, err
+	}
+	return to.Coordinates(v)
+}
@@ -0,0 +1,263 @@
+package linear
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVectorSetStringFunction(t *testing.T) {
+	actual := NewVectorSet(NewVector(1, 0), NewVector(0, 1)).String()
+	expected := "{ [1, 0], [0, 1] }"
+	if actual != expected {
+		t.Errorf("expected '%v', but got '%v'", expected, actual)
+	}
+}
+
+func TestVectorSetIndependenceAndRank(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                VectorSet
+		expectedRank         int
+		expectedIndependent  bool
+		expectedBasis        VectorSet
+		expectedErrorMessage string
+	}{
+		{
+			name:                "empty set",
+			input:               NewVectorSet(),
+			expectedRank:        0,
+			expectedIndependent: true,
+			expectedBasis:       NewVectorSet(),
+		},
+		{
+			name:                "standard basis",
+			input:               NewVectorSet(NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1)),
+			expectedRank:        3,
+			expectedIndependent: true,
+			expectedBasis:       NewVectorSet(NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1)),
+		},
+		{
+			name:                "contains the zero vector",
+			input:               NewVectorSet(NewVector(1, 2), NewVector(0, 0)),
+			expectedRank:        1,
+			expectedIndependent: false,
+			expectedBasis:       NewVectorSet(NewVector(1, 2)),
+		},
+		{
+			name:                "third vector is the sum of the first two",
+			input:               NewVectorSet(NewVector(1, 0, 1), NewVector(0, 1, 1), NewVector(1, 1, 2)),
+			expectedRank:        2,
+			expectedIndependent: false,
+			expectedBasis:       NewVectorSet(NewVector(1, 0, 1), NewVector(0, 1, 1)),
+		},
+		{
+			name:                "parallel vectors are skipped",
+			input:               NewVectorSet(NewVector(1, 2, 3, 4), NewVector(2, 4, 6, 8), NewVector(0, 0, 1, 0)),
+			expectedRank:        2,
+			expectedIndependent: false,
+			expectedBasis:       NewVectorSet(NewVector(1, 2, 3, 4), NewVector(0, 0, 1, 0)),
+		},
+		{
+			name:                "more vectors than dimensions",
+			input:               NewVectorSet(NewVector(1, 1), NewVector(1, -1), NewVector(3, 5)),
+			expectedRank:        2,
+			expectedIndependent: false,
+			expectedBasis:       NewVectorSet(NewVector(1, 1), NewVector(1, -1)),
+		},
+		{
+			name:                 "mismatched dimensions",
+			input:                NewVectorSet(NewVector(1, 1), NewVector(1, -1, 0)),
+			expectedErrorMessage: "all vectors in the set need to have the same number of dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		rank, err := test.input.Rank()
+		if err != nil {
+			if test.expectedErrorMessage == "" || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		if rank != test.expectedRank {
+			t.Errorf("%s: expected rank %d, but got %d", test.name, test.expectedRank, rank)
+		}
+
+		independent, err := test.input.IsLinearlyIndependent()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if independent != test.expectedIndependent {
+			t.Errorf("%s: expected independent to be %v, but got %v", test.name, test.expectedIndependent, independent)
+		}
+
+		basis, err := test.input.Basis()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if len(basis) != len(test.expectedBasis) {
+			t.Fatalf("%s: expected basis %v, but got %v", test.name, test.expectedBasis, basis)
+		}
+		for i := range basis {
+			if !basis[i].Eq(test.expectedBasis[i]) {
+				t.Errorf("%s: expected basis %v, but got %v", test.name, test.expectedBasis, basis)
+			}
+		}
+	}
+}
+
+func TestVectorSetSpanContainsFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                VectorSet
+		v                    Vector
+		expected             bool
+		expectedErrorMessage string
+	}{
+		{
+			name:     "empty set only contains the zero vector",
+			input:    NewVectorSet(),
+			v:        NewVector(0, 0),
+			expected: true,
+		},
+		{
+			name:     "empty set does not contain other vectors",
+			input:    NewVectorSet(),
+			v:        NewVector(1, 0),
+			expected: false,
+		},
+		{
+			name:     "in the xy plane",
+			input:    NewVectorSet(NewVector(1, 0, 0), NewVector(1, 1, 0)),
+			v:        NewVector(5, -3, 0),
+			expected: true,
+		},
+		{
+			name:     "out of the xy plane",
+			input:    NewVectorSet(NewVector(1, 0, 0), NewVector(1, 1, 0)),
+			v:        NewVector(5, -3, 1),
+			expected: false,
+		},
+		{
+			name:     "dependent set",
+			input:    NewVectorSet(NewVector(1, 2), NewVector(2, 4)),
+			v:        NewVector(-3, -6),
+			expected: true,
+		},
+		{
+			name:                 "mismatched dimensions",
+			input:                NewVectorSet(NewVector(1, 2), NewVector(2, 4)),
+			v:                    NewVector(-3, -6, 1),
+			expectedErrorMessage: "the vector has 3 dimensions, but the vectors in the set have 2 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.input.SpanContains(test.v)
+		if err != nil {
+			if test.expectedErrorMessage == "" || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestVectorSetCoordinatesFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		basis                VectorSet
+		v                    Vector
+		expected             Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:     "standard basis",
+			basis:    NewVectorSet(NewVector(1, 0), NewVector(0, 1)),
+			v:        NewVector(3, 4),
+			expected: NewVector(3, 4),
+		},
+		{
+			name:     "rotated basis",
+			basis:    NewVectorSet(NewVector(1, 1), NewVector(1, -1)),
+			v:        NewVector(3, 1),
+			expected: NewVector(2, 1),
+		},
+		{
+			name:     "basis of a plane in 3D",
+			basis:    NewVectorSet(NewVector(1, 0, 1), NewVector(0, 1, 1)),
+			v:        NewVector(2, 3, 5),
+			expected: NewVector(2, 3),
+		},
+		{
+			name:                 "not in the span",
+			basis:                NewVectorSet(NewVector(1, 0, 1), NewVector(0, 1, 1)),
+			v:                    NewVector(2, 3, 4),
+			expectedErrorMessage: "the vector [2, 3, 4] is not in the span of the basis",
+		},
+		{
+			name:                 "not a basis",
+			basis:                NewVectorSet(NewVector(1, 1), NewVector(2, 2)),
+			v:                    NewVector(3, 3),
+			expectedErrorMessage: "the vectors in the basis are not linearly independent",
+		},
+		{
+			name:                 "empty basis",
+			basis:                NewVectorSet(),
+			v:                    NewVector(3, 3),
+			expectedErrorMessage: "the basis must contain at least one vector",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.basis.Coordinates(test.v)
+		if err != nil {
+			if test.expectedErrorMessage == "" || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		if test.expectedErrorMessage != "" {
+			t.Errorf("%s: expected error '%v', but got nil", test.name, test.expectedErrorMessage)
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestVectorSetChangeBasisFunction(t *testing.T) {
+	from := NewVectorSet(NewVector(1, 1), NewVector(1, -1))
+	to := NewVectorSet(NewVector(2, 0), NewVector(0, 4))
+
+	// 2(1, 1) + 1(1, -1) = (3, 1) = 1.5(2, 0) + 0.25(0, 4)
+	actual, err := from.ChangeBasis(NewVector(2, 1), to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := NewVector(1.5, 0.25); !actual.Eq(expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+
+	// Changing back again returns the original coordinates.
+	back, err := to.ChangeBasis(actual, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := NewVector(2, 1); !back.Eq(expected) {
+		t.Errorf("expected %v, but got %v", expected, back)
+	}
+
+	_, err = NewVectorSet(NewVector(1, 1), NewVector(2, 2)).ChangeBasis(NewVector(1, 1), to)
+	if err == nil || err.Error() != "the vectors in the basis are not linearly independent" {
+		t.Errorf("expected an error because the basis is not linearly independent, but got %v", err)
+	}
+
+	_, err = from.ChangeBasis(NewVector(1, 1, 1), to)
+	if err == nil || err.Error() != "cannot create a linear combination of 2 vectors from 3 coefficients" {
+		t.Errorf("expected an error because the coordinates have the wrong dimensions, but got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// GeneralizedCrossProduct calculates the vector which is orthogonal to each of the n-1 input vectors, where each input
+// vector has n dimensions. It is the generalization of the cross product to dimensions other than 3 and is calculated
+// as the formal determinant of the matrix whose first row is the standard basis vectors and whose remaining rows are
+// the inputs, so that for two 3D vectors the result is the same as CrossProduct. The magnitude of the result is the
+// (n-1)-volume of the parallelotope spanned by the inputs, and the result is the zero vector if the inputs are linearly
+// dependent.
+func GeneralizedCrossProduct(vectors ...Vector) (Vector, error) {
+	n := len(vectors) + 1
+	if n < 2 {
+		return Vector{}, errors.New("the generalized cross product requires at least one vector")
+	}
+	for i, v := range vectors {
+		if len(v) != n {
+			return Vector{}, fmt.Errorf("the generalized cross product of %d vectors requires vectors with %d dimensions, but the vector at index %d has %d dimensions", len(vectors), n, i, len(v))
+		}
+	}
+
+	op := make([]float64, n)
+	minor := make([][]float64, n-1)
+	for i := 0; i < n; i++ {
+		// Remove column i from the inputs to create the minor.
+		for r, v := range vectors {
+			row := make([]float64, 0, n-1)
+			row = append(row, v[:i]...)
+			row = append(row, v[i+1:]...)
+			minor[r] = row
+		}
+		cofactor := determinant(minor)
+		if i%2 == 1 {
+			cofactor = -cofactor
+		}
+		if cofactor == 0 {
+			// Avoid -0 in the output.
+			cofactor = 0
+		}
+		op[i] = cofactor
+	}
+	return Vector(op), nil
+}
+
+// Volume calculates the k-dimensional volume of the parallelotope spanned by k vectors which each have n dimensions,
+// e.g. the length of a single vector, the area of the parallelogram spanned by two vectors, or the volume of the
+// parallelepiped spanned by three vectors. The volume is the square root of the determinant of the Gram matrix, whose
+// elements are the dot products of each pair of vectors. If the vectors are linearly dependent, the volume is zero.
+func Volume(vectors ...Vector) (float64, error) {
+	if len(vectors) == 0 {
+		return 0, errors.New("the volume requires at least one vector")
+	}
+	for i, v := range vectors {
+		if len(v) != len(vectors[0]) {
+			return 0, fmt.Errorf("cannot calculate the volume because the vectors have different dimensions (%d at index 0 and %d at index %d)", len(vectors[0]), len(v), i)
+		}
+	}
+
+	gram := make([][]float64, len(vectors))
+	for i, a := range vectors {
+		gram[i] = make([]float64, len(vectors))
+		for j, b := range vectors {
+			// No need to check the error, the dimensions are checked above.
+			gram[i][j], _ = a.DotProduct(b)
+		}
+	}
+	// Rounding errors can make the determinant of a Gram matrix of dependent vectors slightly negative.
+	return math.Sqrt(math.Max(determinant(gram), 0)), nil
+}
@@ -0,0 +1,150 @@
+package linear
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/a-h/linear/tolerance"
+)
+
+func TestGeneralizedCrossProductFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                []Vector
+		expected             Vector
+		expectedErrorMessage string
+	}{
+		{
+			name:     "2D vectors are rotated by 90 degrees",
+			input:    []Vector{NewVector(3, 1)},
+			expected: NewVector(1, -3),
+		},
+		{
+			name:     "3D matches the cross product",
+			input:    []Vector{NewVector(5, 3, -2), NewVector(-1, 0, 3)},
+			expected: NewVector(9, -13, 3),
+		},
+		{
+			name:     "4D standard basis",
+			input:    []Vector{NewVector(1, 0, 0, 0), NewVector(0, 1, 0, 0), NewVector(0, 0, 1, 0)},
+			expected: NewVector(0, 0, 0, -1),
+		},
+		{
+			name:     "4D dependent vectors produce the zero vector",
+			input:    []Vector{NewVector(1, 2, 3, 4), NewVector(2, 4, 6, 8), NewVector(0, 0, 1, 0)},
+			expected: NewVector(0, 0, 0, 0),
+		},
+		{
+			name:                 "no vectors",
+			input:                []Vector{},
+			expectedErrorMessage: "the generalized cross product requires at least one vector",
+		},
+		{
+			name:                 "wrong dimensions",
+			input:                []Vector{NewVector(1, 2, 3), NewVector(1, 2, 3, 4)},
+			expectedErrorMessage: "the generalized cross product of 2 vectors requires vectors with 3 dimensions, but the vector at index 1 has 4 dimensions",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := GeneralizedCrossProduct(test.input...)
+		if err != nil {
+			if test.expectedErrorMessage == "" || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		if !actual.Eq(test.expected) {
+			t.Errorf("%s: for the generalized cross product of %v, expected %v, but got %v", test.name, test.input, test.expected, actual)
+		}
+		for _, v := range test.input {
+			if orthogonal, _ := actual.IsOrthogonalTo(v); !orthogonal {
+				t.Errorf("%s: expected %v to be orthogonal to %v", test.name, actual, v)
+			}
+		}
+	}
+}
+
+func TestGeneralizedCrossProductMagnitudeIsTheVolume(t *testing.T) {
+	input := []Vector{NewVector(1, 2, 0, 1), NewVector(0, 1, 3, -1), NewVector(2, 0, 1, 1)}
+	cp, err := GeneralizedCrossProduct(input...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	volume, err := Volume(input...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tolerance.IsWithin(cp.Magnitude(), volume, DefaultTolerance) {
+		t.Errorf("expected the magnitude of the generalized cross product (%v) to equal the volume (%v)", cp.Magnitude(), volume)
+	}
+}
+
+func TestVolumeFunction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                []Vector
+		expected             float64
+		expectedErrorMessage string
+	}{
+		{
+			name:     "length of a single vector",
+			input:    []Vector{NewVector(3, 4)},
+			expected: 5,
+		},
+		{
+			name:     "area of a 2D parallelogram",
+			input:    []Vector{NewVector(3, 0), NewVector(1, 3)},
+			expected: 9,
+		},
+		{
+			name:     "area of a parallelogram matches the cross product",
+			input:    []Vector{NewVector(5, 3, -2), NewVector(-1, 0, 3)},
+			expected: math.Sqrt((9 * 9) + (-13 * -13) + (3 * 3)),
+		},
+		{
+			name:     "volume of a cube",
+			input:    []Vector{NewVector(2, 0, 0), NewVector(0, 2, 0), NewVector(0, 0, 2)},
+			expected: 8,
+		},
+		{
+			name:     "area of a square in 4D",
+			input:    []Vector{NewVector(0, 2, 0, 0), NewVector(0, 0, 0, 2)},
+			expected: 4,
+		},
+		{
+			name:     "dependent vectors have no volume",
+			input:    []Vector{NewVector(1, 2, 3), NewVector(2, 4, 6)},
+			expected: 0,
+		},
+		{
+			name:     "more vectors than dimensions have no volume",
+			input:    []Vector{NewVector(1, 0), NewVector(0, 1), NewVector(1, 1)},
+			expected: 0,
+		},
+		{
+			name:                 "no vectors",
+			input:                []Vector{},
+			expectedErrorMessage: "the volume requires at least one vector",
+		},
+		{
+			name:                 "mismatched dimensions",
+			input:                []Vector{NewVector(1, 0), NewVector(0, 1, 0)},
+			expectedErrorMessage: "cannot calculate the volume because the vectors have different dimensions (2 at index 0 and 3 at index 1)",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := Volume(test.input...)
+		if err != nil {
+			if test.expectedErrorMessage == "" || !strings.HasPrefix(err.Error(), test.expectedErrorMessage) {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		if !tolerance.IsWithin(actual, test.expected, DefaultTolerance) {
+			t.Errorf("%s: for the volume of %v, expected %v, but got %v", test.name, test.input, test.expected, actual)
+		}
+	}
+}